@@ -0,0 +1,109 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// allowCmd represents the allow command
+func NewAllowCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var allowCmd = &cobra.Command{
+		Use:   "allow [path]",
+		Short: "Trust the .zest.yaml of a project",
+		Long: `Trusts the .zest.yaml of a project, the one in the given directory or the
+current one, or in the closest of their parents. Project files come with
+repositories and run commands, so zest refuses to use them until they are
+allowed. Any change to the file, or to the files it extends and includes,
+has to be allowed again, review them first.
+
+Use --revoke to stop trusting a file.`,
+		Example: `  zest allow
+  zest allow ~/src/api
+  zest allow --revoke`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			revoke, err := cmd.Flags().GetBool("revoke")
+			if err != nil {
+				return err
+			}
+
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			path, err = findProjectFile(path)
+			if err != nil {
+				return err
+			}
+
+			if revoke {
+				if err := launch.Revoke(cfg, path); err != nil {
+					return fmt.Errorf("failed to revoke %s: %w", path, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Revoked %s.\n", path)
+				return nil
+			}
+
+			if err := launch.Allow(cfg, path); err != nil {
+				return fmt.Errorf("failed to allow %s: %w", path, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Allowed %s.\n", path)
+			return nil
+		},
+	}
+
+	allowCmd.Flags().Bool("revoke", false, "Stop trusting the project file")
+	return allowCmd
+}
+
+// findProjectFile returns the project file at path, or the one found from
+// the directory path or the current directory upwards.
+func findProjectFile(path string) (string, error) {
+	if path == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		path = wd
+	}
+
+	path, err := filepath.Abs(utils.ExpandHome(path))
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	return workspace.FindProject(path)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// appCmd represents the app command
+func NewAppCmd(cfg *utils.ZestConfig) *cobra.Command {
+	appCmd := &cobra.Command{
+		Use:   "app",
+		Short: "Start and stop single apps of an active workspace",
+		Long: `Manages the individual apps of a workspace that is already active.
+
+Apps are addressed by their id. An app without an 'id' in the workspace file is
+named after its type, or after its type and a hash of its fields when there
+are several (e.g. browser-3fa2c1). Use 'zest app list' to see them.
+
+Starting or stopping an app only touches that app, the rest of the workspace
+keeps running and its runtime state is updated in place.`,
+		Example: `  zest app list work
+  zest app start work browser
+  zest app stop work server
+  zest app stop work server --grace 10s`,
+		Args: cobra.NoArgs,
+	}
+
+	appCmd.AddCommand(newAppListCmd(cfg))
+	appCmd.AddCommand(newAppStartCmd(cfg))
+	appCmd.AddCommand(newAppStopCmd(cfg))
+
+	return appCmd
+}
+
+func newAppListCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [workspace-name]",
+		Short: "List the apps of a workspace and whether they are running",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wspName := args[0]
+
+			wspCfg, wspRt, err := loadWorkspaceState(cfg, wspName)
+			if err != nil {
+				return err
+			}
+
+			profile := ""
+			if wspRt != nil {
+				profile = wspRt.Profile
+			}
+			plan, err := launch.NewLaunchPlan(cfg, wspName, profile)
+			if err != nil {
+				return fmt.Errorf("failed to create launch plan for '%s': %w", wspName, err)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "ID\tTYPE\tSTATUS\tPIDS\tTAGS")
+			for _, app := range plan.Apps {
+				meta := app.Meta()
+				status, pids := "stopped", "-"
+				if wspCfg.Status == workspace.Active && wspRt != nil {
+					if i := wspRt.FindApp(meta.ID); i >= 0 && i < len(wspRt.PIDs) {
+						status, pids = "running", joinInts(wspRt.PIDs[i])
+					}
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+					meta.ID, app.GetName(), status, pids, wrapEmptyOutput(strings.Join(meta.Tags, ",")))
+			}
+			return tw.Flush()
+		},
+	}
+}
+
+func newAppStartCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "start [workspace-name] [app-id]",
+		Short: "Start a single app in an active workspace",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return startApp(cmd.OutOrStdout(), cfg, args[0], args[1])
+		},
+	}
+}
+
+func newAppStopCmd(cfg *utils.ZestConfig) *cobra.Command {
+	stopCmd := &cobra.Command{
+		Use:   "stop [workspace-name] [app-id]",
+		Short: "Stop a single app in an active workspace",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stopApp(cmd.OutOrStdout(), cfg, args[0], args[1], cfg.Settings.Close.GracePeriod)
+		},
+	}
+
+	stopCmd.Flags().Duration("grace", 0, "Time the app gets to exit before it is killed (default from close.grace_period)")
+	bindSetting(stopCmd, "grace", "close.grace_period")
+	return stopCmd
+}
+
+// loadWorkspaceState returns the registry entry of a workspace and, when it
+// is active, its runtime.
+func loadWorkspaceState(cfg *utils.ZestConfig, wspName string) (*workspace.WspConfig, *workspace.WspRuntime, error) {
+	wspReg, err := workspace.NewWspRegistry(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load workspace registry: %w", err)
+	}
+	wspCfg, ok := wspReg.GetCfg(wspName)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: '%s'", workspace.ErrWorkspaceNotExists, wspName)
+	}
+	if wspCfg.Status != workspace.Active {
+		return wspCfg, nil, nil
+	}
+
+	wspRt, err := workspace.NewWspRuntime(cfg, wspName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize runtime for '%s': %w", wspName, err)
+	}
+	if err := wspRt.Load(); err != nil {
+		return nil, nil, fmt.Errorf("failed to load runtime for '%s': %w", wspName, err)
+	}
+	return wspCfg, wspRt, nil
+}
+
+func startApp(w io.Writer, cfg *utils.ZestConfig, wspName, id string) error {
+	_, wspRt, err := loadWorkspaceState(cfg, wspName)
+	if err != nil {
+		return err
+	}
+	if wspRt == nil {
+		return fmt.Errorf("%w: '%s', launch it first", workspace.ErrWorkspaceIsInactive, wspName)
+	}
+
+	plan, err := sessionPlan(cfg, wspRt)
+	if err != nil {
+		return err
+	}
+	app, ok := plan.App(id)
+	if !ok {
+		return fmt.Errorf("%w '%s' in workspace '%s', available: %s",
+			launch.ErrNoMatchingApp, id, wspName, strings.Join(plan.GetAppIDs(), ", "))
+	}
+
+	closeLog, err := attachSessionLog(cfg, wspName, plan, "app start "+id)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	return wspRt.Transact(func() error {
+		if i := wspRt.FindApp(id); i >= 0 && i < len(wspRt.PIDs) {
+			for _, pid := range wspRt.PIDs[i] {
+				if utils.IsRunning(pid) {
+					return fmt.Errorf("app '%s' is already running in workspace '%s'", id, wspName)
+				}
+			}
+		}
+
+		fmt.Fprintf(w, "Starting app '%s' in workspace '%s'...\n", id, wspName)
+		return runApp(w, wspRt, plan, app)
+	})
+}
+
+func stopApp(w io.Writer, cfg *utils.ZestConfig, wspName, id string, grace time.Duration) error {
+	_, wspRt, err := loadWorkspaceState(cfg, wspName)
+	if err != nil {
+		return err
+	}
+	if wspRt == nil {
+		return fmt.Errorf("%w: '%s'", workspace.ErrWorkspaceIsInactive, wspName)
+	}
+
+	// the app is stopped even if the spec no longer builds, only its hooks are skipped
+	plan, err := sessionPlan(cfg, wspRt)
+	if err != nil {
+		fmt.Fprintf(w, "Warning: skipping the hooks of app '%s': %v\n", id, err)
+		plan = nil
+	} else {
+		closeLog, err := attachSessionLog(cfg, wspName, plan, "app stop "+id)
+		if err != nil {
+			return err
+		}
+		defer closeLog()
+	}
+
+	return wspRt.Transact(func() error {
+		fmt.Fprintf(w, "Stopping app '%s' in workspace '%s'...\n", id, wspName)
+		if err := killApp(w, wspRt, plan, id, grace); err != nil {
+			return err
+		}
+		wspRt.MarkStopped(id)
+		return nil
+	})
+}
+
+// sessionPlan builds the plan of an active workspace with the profile and
+// environment it was launched with.
+func sessionPlan(cfg *utils.ZestConfig, wspRt *workspace.WspRuntime) (*launch.Plan, error) {
+	plan, err := launch.NewLaunchPlan(cfg, wspRt.Name, wspRt.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create launch plan for '%s': %w", wspRt.Name, err)
+	}
+	if len(wspRt.Launch.Env) > 0 {
+		plan.ApplyEnv(wspRt.Launch.Env)
+	}
+	return plan, nil
+}
+
+// runApp starts a single app and records it in the runtime, without saving,
+// call it inside WspRuntime.Transact.
+func runApp(w io.Writer, wspRt *workspace.WspRuntime, plan *launch.Plan, app launch.AppSpec) error {
+	id := app.Meta().ID
+	startErr := plan.StartApp(app)
+	if startErr != nil && !errors.Is(startErr, launch.ErrPostLaunch) {
+		return fmt.Errorf("failed to start app '%s': %w", id, startErr)
+	}
+	if err := wspRt.SetApp(id, app.GetName(), plan.Hash(app), plan.EnvHash(), app.GetPIDs()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "App '%s' started (PIDs: %s).\n", id, joinInts(app.GetPIDs()))
+	return startErr
+}
+
+// killApp stops a single app and drops it from the runtime, without saving,
+// call it inside WspRuntime.Transact.
+// The close hooks of the app run when plan still has it, plan may be nil.
+func killApp(w io.Writer, wspRt *workspace.WspRuntime, plan *launch.Plan, id string, grace time.Duration) error {
+	var app launch.AppSpec
+	if plan != nil && wspRt.FindApp(id) >= 0 {
+		app, _ = plan.App(id)
+	}
+	if app != nil {
+		if err := plan.RunAppHooks(app, launch.PreClose); err != nil {
+			return err
+		}
+	}
+
+	pids, err := wspRt.RemoveApp(id)
+	if err != nil {
+		return err
+	}
+
+	errs := utils.Stop(pids, grace)
+	for _, pid := range pids {
+		if err, ok := errs[pid]; ok {
+			fmt.Fprintf(w, "Warning: failed to kill PID %d of app '%s': %v\n", pid, id, err)
+		}
+	}
+
+	fmt.Fprintf(w, "App '%s' stopped.\n", id)
+	if app != nil {
+		return plan.RunAppHooks(app, launch.PostClose)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// applyCmd represents the apply command
+func NewApplyCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var applyCmd = &cobra.Command{
+		Use:   "apply [workspace-name]",
+		Short: "Bring an active workspace in line with its spec",
+		Long: `Starts, stops and restarts the apps of an active workspace so it matches its
+edited workspace file, leaving apps whose config and env did not change alone.
+See 'zest diff' for what would change.`,
+		Example: `  zest apply work
+  zest apply work --dry-run
+  zest apply work --grace 10s`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+			return applyWorkspace(cmd.OutOrStdout(), cfg, args[0], dryRun)
+		},
+	}
+
+	applyCmd.Flags().Bool("dry-run", false, "Show the changes without making them")
+	applyCmd.Flags().Duration("grace", 0, "Time apps get to exit before they are killed (default from close.grace_period)")
+	bindSetting(applyCmd, "grace", "close.grace_period")
+	return applyCmd
+}
+
+func applyWorkspace(w io.Writer, cfg *utils.ZestConfig, wspName string, dryRun bool) error {
+	wspRt, plan, diff, err := diffWorkspace(cfg, wspName)
+	if err != nil {
+		return err
+	}
+
+	printDiff(w, diff)
+	if diff.Apps() == 0 || dryRun {
+		return nil
+	}
+
+	closeLog, err := attachSessionLog(cfg, wspName, plan, "apply")
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	grace := cfg.Settings.Close.GracePeriod
+	err = wspRt.Transact(func() error {
+		// another zest may have changed the apps since the diff was shown
+		if diff, err = wspRt.Diff(plan); err != nil {
+			return err
+		}
+		for _, id := range diff.Removed {
+			if err := killApp(w, wspRt, plan, id, grace); err != nil {
+				return err
+			}
+		}
+		for _, id := range diff.Restarted() {
+			if err := killApp(w, wspRt, plan, id, grace); err != nil {
+				return err
+			}
+		}
+		for _, id := range append(diff.Restarted(), diff.Added...) {
+			app, _ := plan.App(id)
+			if err := runApp(w, wspRt, plan, app); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply workspace '%s': %w", wspName, err)
+	}
+
+	fmt.Fprintf(w, "Workspace '%s' applied: %d added, %d removed, %d changed.\n",
+		wspName, len(diff.Added), len(diff.Removed), len(diff.Restarted()))
+	return nil
+}
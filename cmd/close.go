@@ -22,10 +22,12 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"time"
 
+	"github.com/AVAniketh0905/zest/internal/launch"
 	"github.com/AVAniketh0905/zest/internal/utils"
 	"github.com/AVAniketh0905/zest/internal/workspace"
 	"github.com/spf13/cobra"
@@ -38,10 +40,12 @@ func NewCloseCmd(cfg *utils.ZestConfig) *cobra.Command {
 		Short: "Close an existing or active workspace",
 		Long: `Close a specific workspace by name, or use --all to close all workspaces.
 
-Closing a workspace will stop its processes and mark it as inactive.`,
+Closing a workspace will stop its processes and mark it as inactive. A failing
+pre_close hook is reported, the workspace is closed anyway.`,
 		Example: `  zest close work
   zest close personal
-  zest close --all`,
+  zest close --all
+  zest close work --grace 10s`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := cmd.ValidateArgs(args); err != nil {
@@ -85,6 +89,8 @@ Closing a workspace will stop its processes and mark it as inactive.`,
 	}
 
 	closeCmd.Flags().Bool("all", false, "Close all currently open workspaces")
+	closeCmd.Flags().Duration("grace", 0, "Time apps get to exit before they are killed (default from close.grace_period)")
+	bindSetting(closeCmd, "grace", "close.grace_period")
 	return closeCmd
 }
 
@@ -105,12 +111,35 @@ func closeWorkspace(cfg *utils.ZestConfig, wspReg *workspace.WspRegistry, wspCfg
 		return fmt.Errorf("failed to load runtime for '%s': %w", wspCfg.Name, err)
 	}
 
-	// Kill all associated processes
-	for _, pids := range wspRt.PIDs {
-		for _, pid := range pids {
-			if err := utils.Kill(pid); err != nil {
-				fmt.Fprintf(w, "Warning: failed to kill PID %d for workspace '%s': %v\n", pid, wspCfg.Name, err)
-			}
+	// The workspace is closed even if its spec no longer builds, only its hooks are skipped
+	var hookErr error
+	plan, err := sessionPlan(cfg, wspRt)
+	if err != nil {
+		fmt.Fprintf(w, "Warning: skipping the hooks of '%s': %v\n", wspCfg.Name, err)
+		plan = nil
+	} else {
+		closeLog, err := attachSessionLog(cfg, wspCfg.Name, plan, "close")
+		if err != nil {
+			return err
+		}
+		defer closeLog()
+
+		// only the apps that are running
+		if len(wspRt.Apps) == len(wspRt.PIDs) {
+			plan.Keep(wspRt.Apps)
+		}
+		// a broken pre_close hook must not keep the apps running
+		if hookErr = runCloseHooks(w, plan, launch.PreClose); hookErr != nil {
+			fmt.Fprintf(w, "Warning: %v, closing anyway\n", hookErr)
+		}
+	}
+
+	// Stop all associated processes, killing whatever outlives the grace period
+	pids := flatten(wspRt.PIDs)
+	errs := utils.Stop(pids, cfg.Settings.Close.GracePeriod)
+	for _, pid := range pids {
+		if err, ok := errs[pid]; ok {
+			fmt.Fprintf(w, "Warning: failed to kill PID %d for workspace '%s': %v\n", pid, wspCfg.Name, err)
 		}
 	}
 
@@ -120,14 +149,54 @@ func closeWorkspace(cfg *utils.ZestConfig, wspReg *workspace.WspRegistry, wspCfg
 	}
 
 	// Update registry
-	wspCfg.Status = workspace.Inactive
-	wspCfg.LastUsed = time.Now().Format(time.RFC3339)
-	wspReg.Update(wspCfg)
-
-	if err := wspReg.Save(); err != nil {
+	err = wspReg.Transact(func() error {
+		entry, ok := wspReg.GetCfg(wspCfg.Name)
+		if !ok {
+			return workspace.ErrWorkspaceNotExists
+		}
+		entry.Status = workspace.Inactive
+		entry.LastUsed = time.Now().Format(time.RFC3339)
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update registry: %w", err)
 	}
 
+	if plan != nil {
+		hookErr = errors.Join(hookErr, runCloseHooks(w, plan, launch.PostClose))
+	}
+	if hookErr != nil {
+		return fmt.Errorf("workspace '%s' closed, but %w", wspCfg.Name, hookErr)
+	}
+	return nil
+}
+
+// runCloseHooks runs the workspace hooks of stage around the hooks of the
+// apps, mirroring launch: the workspace comes first for pre_close and last
+// for post_close.
+func runCloseHooks(w io.Writer, plan *launch.Plan, stage string) error {
+	count := len(plan.Hooks.Stage(stage))
+	for _, app := range plan.Apps {
+		count += len(app.Meta().Hooks.Stage(stage))
+	}
+	if count == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "Running %d %s hook(s)...\n", count, stage)
+
+	if stage == launch.PreClose {
+		if err := plan.RunHooks(stage); err != nil {
+			return err
+		}
+	}
+	for _, app := range plan.Apps {
+		if err := plan.RunAppHooks(app, stage); err != nil {
+			return err
+		}
+	}
+	if stage == launch.PostClose {
+		return plan.RunHooks(stage)
+	}
 	return nil
 }
 
@@ -0,0 +1,429 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configCmd represents the config command
+func NewConfigCmd(cfg *utils.ZestConfig) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Get and set global zest options",
+		Long: `Reads and writes the global configuration in zest.yaml, the way git config does.
+
+Keys use dots for nesting, e.g. launch.parallelism or env.EDITOR. Values are
+written straight into zest.yaml, keeping any comments already in the file.
+
+The effective value of a key comes from, in order of precedence: a command-line
+flag, a ZEST_* environment variable, zest.yaml, and finally the built-in default.
+Most flags only apply to the command they are given to, so --show-origin
+reports a flag only for global ones such as --output.`,
+		Example: `  zest config list
+  zest config list --show-origin
+  zest config list --show-origin --output json
+  zest config get close.grace_period
+  zest config set launch.parallelism 4
+  zest config set env.EDITOR nvim
+  zest config unset list.sort
+  zest config edit
+  zest config path`,
+		Args: cobra.NoArgs,
+	}
+
+	configCmd.AddCommand(newConfigGetCmd(cfg))
+	configCmd.AddCommand(newConfigSetCmd(cfg))
+	configCmd.AddCommand(newConfigUnsetCmd(cfg))
+	configCmd.AddCommand(newConfigListCmd(cfg))
+	configCmd.AddCommand(newConfigEditCmd(cfg))
+	configCmd.AddCommand(newConfigPathCmd(cfg))
+
+	return configCmd
+}
+
+func newConfigGetCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [key]",
+		Short: "Print the effective value of a config key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			if _, _, err := parseSettingKey(key); err != nil {
+				return err
+			}
+
+			value, ok := settingValues(cfg.Settings)[key]
+			if !ok {
+				return fmt.Errorf("config key '%s' is not set", key)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), value)
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: "Set a config key in zest.yaml",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+
+			path, kind, err := parseSettingKey(key)
+			if err != nil {
+				return err
+			}
+			node, err := settingNode(kind, value)
+			if err != nil {
+				return fmt.Errorf("invalid value for '%s': %w", key, err)
+			}
+
+			return editConfigFile(configPath(cfg), func(root *yaml.Node) error {
+				utils.SetMappingPath(root, path, node)
+				return nil
+			})
+		},
+	}
+}
+
+func newConfigUnsetCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset [key]",
+		Short: "Remove a config key from zest.yaml",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+
+			path, _, err := parseSettingKey(key)
+			if err != nil {
+				return err
+			}
+
+			file := configPath(cfg)
+			return editConfigFile(file, func(root *yaml.Node) error {
+				if !utils.UnsetMappingPath(root, path) {
+					return fmt.Errorf("config key '%s' is not set in %s", key, file)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newConfigListCmd(cfg *utils.ZestConfig) *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every config key with its effective value",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			showOrigin, err := cmd.Flags().GetBool("show-origin")
+			if err != nil {
+				return err
+			}
+
+			v, err := newViper(cfg, cfgFile, cmd)
+			if err != nil {
+				return err
+			}
+
+			values := settingValues(cfg.Settings)
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			for _, key := range keys {
+				if showOrigin {
+					fmt.Fprintf(tw, "%s\t%s=%s\n", settingOrigin(v, cmd, cfg.ConfigFile, key), key, values[key])
+				} else {
+					fmt.Fprintf(tw, "%s=%s\n", key, values[key])
+				}
+			}
+			return tw.Flush()
+		},
+	}
+
+	listCmd.Flags().Bool("show-origin", false, "Show where each value comes from: default, file, env or flag")
+
+	return listCmd
+}
+
+func newConfigEditCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: "Open zest.yaml in $VISUAL or $EDITOR",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := configPath(cfg)
+			if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+				if err := os.WriteFile(file, []byte("# zest global configuration\n"), 0644); err != nil {
+					return err
+				}
+			}
+
+			editor := strings.Fields(preferredEditor())
+			editCmd := exec.Command(editor[0], append(editor[1:], file)...)
+			editCmd.Stdin = cmd.InOrStdin()
+			editCmd.Stdout = cmd.OutOrStdout()
+			editCmd.Stderr = cmd.ErrOrStderr()
+			if err := editCmd.Run(); err != nil {
+				return fmt.Errorf("editor exited with an error: %w", err)
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if err := validateConfig(data); err != nil {
+				return fmt.Errorf("%s is invalid, run 'zest config edit' again to fix it: %w", file, err)
+			}
+			return nil
+		},
+	}
+}
+
+func newConfigPathCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "path",
+		Short: "Print the path of the config file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintln(cmd.OutOrStdout(), configPath(cfg))
+			return nil
+		},
+	}
+}
+
+// settingKind is the type a config value is parsed as.
+type settingKind int
+
+const (
+	stringSetting settingKind = iota
+	intSetting
+	boolSetting
+	durationSetting
+)
+
+// parseSettingKey validates a dotted config key and splits it into its path.
+func parseSettingKey(key string) ([]string, settingKind, error) {
+	path := strings.Split(key, ".")
+
+	switch path[0] {
+	case "env", "binaries":
+		if len(path) != 2 || path[1] == "" {
+			return nil, 0, fmt.Errorf("config key '%s' must look like %s.<name>", key, path[0])
+		}
+		return path, stringSetting, nil
+	}
+
+	def, ok := settingDefaults()[key]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown config key '%s'", key)
+	}
+
+	switch def.(type) {
+	case int:
+		return path, intSetting, nil
+	case bool:
+		return path, boolSetting, nil
+	case time.Duration:
+		return path, durationSetting, nil
+	case string:
+		return path, stringSetting, nil
+	}
+	return nil, 0, fmt.Errorf("config key '%s' is a section, set one of its keys instead", key)
+}
+
+// settingNode builds the YAML node written for a value of the given kind.
+func settingNode(kind settingKind, value string) (*yaml.Node, error) {
+	switch kind {
+	case intSetting:
+		if _, err := strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("expected a number, got '%s'", value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}, nil
+	case boolSetting:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false, got '%s'", value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(b)}, nil
+	case durationSetting:
+		if _, err := time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("expected a duration like 5s, got '%s'", value)
+		}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+}
+
+// settingValues flattens the effective settings into their zest.yaml keys.
+func settingValues(s utils.Settings) map[string]string {
+	values := map[string]string{
+		"launch.parallelism": strconv.Itoa(s.Launch.Parallelism),
+		"launch.watch":       strconv.FormatBool(s.Launch.Watch),
+		"close.grace_period": s.Close.GracePeriod.String(),
+		"list.sort":          s.List.Sort,
+		"list.filter":        s.List.Filter,
+		"browser":            s.Browser,
+		"terminal":           s.Terminal,
+		"editor":             s.Editor,
+		"output":             s.Output,
+	}
+	for k, v := range s.Env {
+		values["env."+k] = v
+	}
+	for k, v := range s.Binaries {
+		values["binaries."+k] = v
+	}
+	return values
+}
+
+// settingOrigin reports where the effective value of key comes from. Only the
+// flags cmd accepts can override it, which are the global ones for config list.
+func settingOrigin(v *viper.Viper, cmd *cobra.Command, configFile, key string) string {
+	origin := ""
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if origin == "" && slices.Contains(f.Annotations[settingAnnotation], key) {
+			origin = "flag:--" + f.Name
+		}
+	})
+	if origin != "" {
+		return origin
+	}
+
+	if !strings.HasPrefix(key, "env.") && !strings.HasPrefix(key, "binaries.") {
+		envName := "ZEST_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		if _, ok := os.LookupEnv(envName); ok {
+			return "env:" + envName
+		}
+	}
+
+	if configFile != "" && v.InConfig(strings.ToLower(key)) {
+		return "file:" + configFile
+	}
+	return "default"
+}
+
+// configPath returns the config file zest reads and writes.
+func configPath(cfg *utils.ZestConfig) string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	return filepath.Join(cfg.RootDir(), "zest.yaml")
+}
+
+// editConfigFile applies fn to the YAML mapping of the config file and writes
+// it back, refusing to save a result that zest could not load.
+func editConfigFile(file string, fn func(root *yaml.Node) error) error {
+	doc := yaml.Node{}
+	data, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s must contain a mapping at the top level", file)
+	}
+
+	if err := fn(root); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	if err := validateConfig(buf.Bytes()); err != nil {
+		return fmt.Errorf("refusing to write an invalid config: %w", err)
+	}
+	return utils.WriteFileAtomic(file, buf.Bytes(), 0644)
+}
+
+// validateConfig checks that data decodes into valid settings.
+func validateConfig(data []byte) error {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	for key, val := range settingDefaults() {
+		v.SetDefault(key, val)
+	}
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	settings := utils.Settings{}
+	if err := v.Unmarshal(&settings); err != nil {
+		return err
+	}
+	return settings.Validate()
+}
+
+// preferredEditor returns the editor command from $VISUAL or $EDITOR.
+func preferredEditor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
@@ -87,11 +87,13 @@ func deleteWorkspace(cfg *utils.ZestConfig, w io.Writer, wspName string, force b
 	}
 
 	fmt.Fprintf(w, "Deleting workspace '%s'...\n", wspName)
-	if err := wspReg.Delete(wspName); err != nil {
-		return fmt.Errorf("failed to delete workspace '%s': %w", wspName, err)
-	}
-
-	if err := wspReg.Save(); err != nil {
+	err = wspReg.Transact(func() error {
+		if err := wspReg.Delete(wspName); err != nil {
+			return fmt.Errorf("failed to delete workspace '%s': %w", wspName, err)
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save updated registry: %w", err)
 	}
 
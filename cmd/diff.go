@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+func NewDiffCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var diffCmd = &cobra.Command{
+		Use:   "diff [workspace-name]",
+		Short: "Show how an active workspace differs from its spec",
+		Long: `Compares the apps an active workspace is running with its workspace file,
+using the profile and options it was launched with.
+
+  + app is in the spec but not running, it would be started
+  - app is running but no longer in the spec, it would be stopped
+  ~ app config or env changed since it was started, it would be restarted
+
+Apps are restarted for a changed env, from the workspace or its env files,
+since they only read it when they start.
+
+Use 'zest apply' to make the changes.`,
+		Example: `  zest diff work
+  zest diff work --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOut, err := jsonOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			_, _, diff, err := diffWorkspace(cfg, args[0])
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				data, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return nil
+			}
+
+			printDiff(cmd.OutOrStdout(), diff)
+			return nil
+		},
+	}
+
+	diffCmd.Flags().Bool("json", false, "Output in JSON format")
+	return diffCmd
+}
+
+// diffWorkspace compares an active workspace with its spec and returns its
+// runtime and the plan the spec builds.
+func diffWorkspace(cfg *utils.ZestConfig, wspName string) (*workspace.WspRuntime, *launch.Plan, workspace.AppDiff, error) {
+	_, wspRt, err := loadWorkspaceState(cfg, wspName)
+	if err != nil {
+		return nil, nil, workspace.AppDiff{}, err
+	}
+	if wspRt == nil {
+		return nil, nil, workspace.AppDiff{}, fmt.Errorf("%w: '%s'", workspace.ErrWorkspaceIsInactive, wspName)
+	}
+
+	plan, err := sessionPlan(cfg, wspRt)
+	if err != nil {
+		return nil, nil, workspace.AppDiff{}, err
+	}
+	diff, err := wspRt.Diff(plan)
+	if err != nil {
+		return nil, nil, workspace.AppDiff{}, err
+	}
+	return wspRt, plan, diff, nil
+}
+
+func printDiff(w io.Writer, diff workspace.AppDiff) {
+	if diff.IsEmpty() {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+	for _, id := range diff.Added {
+		fmt.Fprintf(w, "+ %s\n", id)
+	}
+	for _, id := range diff.Removed {
+		fmt.Fprintf(w, "- %s\n", id)
+	}
+	for _, id := range diff.Changed {
+		fmt.Fprintf(w, "~ %s\n", id)
+	}
+	for _, id := range diff.Env {
+		fmt.Fprintf(w, "~ %s (env changed)\n", id)
+	}
+}
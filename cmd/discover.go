@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// discoverCmd represents the discover command
+func NewDiscoverCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var discoverCmd = &cobra.Command{
+		Use:   "discover [dir]",
+		Short: "Find projects in a directory tree and create workspaces for them",
+		Long: `Searches a directory, the current one by default, for project roots: directories
+with a .git, go.mod, package.json, pyproject.toml or *.code-workspace. Hidden
+directories, node_modules and vendor are not searched, nor are the directories
+of a project.
+
+Each project gets a workspace named after its directory, with the configured
+editor on its root, a terminal in its root when the configured terminal runs
+on this system, and the web page of its git remote in the browser. Projects
+that already have a workspace or their own .zest.yaml are skipped, and so are
+unreadable directories, with a warning.
+
+zest lists what it would create, run it again with --yes to create it.`,
+		Example: `  zest discover ~/src
+  zest discover ~/src --depth 3 --yes`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			depth, err := cmd.Flags().GetInt("depth")
+			if err != nil {
+				return err
+			}
+			yes, err := cmd.Flags().GetBool("yes")
+			if err != nil {
+				return err
+			}
+
+			dir := ""
+			if len(args) > 0 {
+				dir = args[0]
+			} else if dir, err = os.Getwd(); err != nil {
+				return err
+			}
+			return discoverProjects(cmd.OutOrStdout(), cfg, dir, depth, yes)
+		},
+	}
+
+	discoverCmd.Flags().Int("depth", 3, "How many directory levels to search below the directory")
+	discoverCmd.Flags().BoolP("yes", "y", false, "Create the workspaces instead of listing them")
+	return discoverCmd
+}
+
+func discoverProjects(w io.Writer, cfg *utils.ZestConfig, dir string, depth int, yes bool) error {
+	projects, err := workspace.Discover(cfg, dir, depth)
+	if err != nil {
+		return fmt.Errorf("failed to discover projects: %w", err)
+	}
+	if len(projects) == 0 {
+		fmt.Fprintf(w, "No projects found in %s.\n", dir)
+		return nil
+	}
+
+	count := 0
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORKSPACE\tDIR\tFOUND BY\tACTION")
+	for _, project := range projects {
+		name, action := project.Name, project.Skip
+		if action == "" {
+			count++
+			action = "create: " + strings.Join(slices.Sorted(maps.Keys(project.Spec.Apps)), ", ")
+		} else {
+			name, action = "-", "skip: "+action
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, project.Dir, strings.Join(project.Markers, ", "), action)
+	}
+	tw.Flush()
+
+	switch {
+	case count == 0:
+		fmt.Fprintln(w, "Nothing to create.")
+	case !yes:
+		fmt.Fprintf(w, "Run again with --yes to create %d workspace(s).\n", count)
+	default:
+		if err := workspace.CreateProjects(cfg, projects); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Created %d workspace(s).\n", count)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+func NewDoctorCmd(cfg *utils.ZestConfig) *cobra.Command {
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the registry and workspace files for inconsistencies",
+		Long: `Checks that the workspace registry, the workspace config files and the
+runtime state agree with each other.
+
+This detects config files that are not registered, registry entries whose config
+file is gone, stale runtime files, processes that are no longer running, unknown
+app types, apps that don't run on this system and app executables that can't
+be found.
+
+Use --fix to rebuild the registry and clean up the runtime state.`,
+		Example: `  zest doctor
+  zest doctor --fix
+  zest doctor --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fix, err := cmd.Flags().GetBool("fix")
+			if err != nil {
+				return err
+			}
+			jsonOut, err := jsonOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			return runDoctor(cmd.OutOrStdout(), cfg, fix, jsonOut)
+		},
+	}
+
+	doctorCmd.Flags().Bool("fix", false, "Repair every issue that can be fixed automatically")
+	doctorCmd.Flags().Bool("json", false, "Output in JSON format")
+
+	return doctorCmd
+}
+
+func runDoctor(w io.Writer, cfg *utils.ZestConfig, fix, jsonOut bool) error {
+	issues, err := workspace.Diagnose(cfg)
+	if err != nil {
+		return err
+	}
+
+	fixed := []workspace.Issue{}
+	if fix {
+		fixed, err = workspace.Repair(cfg, issues)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jsonOut {
+		report := struct {
+			Issues []workspace.Issue `json:"issues"`
+			Fixed  []workspace.Issue `json:"fixed"`
+		}{issues, fixed}
+
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal doctor report: %w", err)
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	if len(issues) == 0 {
+		fmt.Fprintln(w, "No issues found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tWORKSPACE\tFIXABLE\tDETAIL")
+	fixable := 0
+	for _, issue := range issues {
+		if issue.Fixable {
+			fixable++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%s\n", issue.Kind, issue.Workspace, issue.Fixable, issue.Detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nFound %d issue(s), %d fixable.\n", len(issues), fixable)
+	switch {
+	case fix:
+		fmt.Fprintf(w, "Fixed %d issue(s).\n", len(fixed))
+	case fixable > 0:
+		fmt.Fprintln(w, "Run 'zest doctor --fix' to repair them.")
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/AVAniketh0905/zest/internal/shellhook"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var ErrNoWorkspaceForDir utils.ZestErr = errors.New("no workspace contains the directory")
+
+// envCmd represents the env command
+func NewEnvCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var (
+		shell string
+		dir   string
+		which bool
+	)
+
+	var envCmd = &cobra.Command{
+		Use:   "env [workspace-name]",
+		Short: "Print the environment of a workspace as shell exports",
+		Long: `Prints the environment of a workspace as exports for the shell to eval:
+the global env, the workspace env and its env files, and ZEST_WORKSPACE set to
+the workspace name. An active workspace also keeps the --env it was launched
+with.
+
+Without a name, the workspace whose workspace_dir holds the current directory
+is used, the one with the longest workspace_dir when they nest. --which prints
+that workspace and whether it is active instead, or nothing if there is none.`,
+		Example: `  eval "$(zest env api)"
+  zest env api --shell fish | source
+  zest env --which`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				wd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				dir = wd
+			}
+
+			if which {
+				if len(args) > 0 {
+					return fmt.Errorf("--which finds the workspace of a directory, use --dir instead of a name")
+				}
+				wspCfg, err := workspace.MatchDir(cfg, dir)
+				if err != nil {
+					return err
+				}
+				if wspCfg != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", wspCfg.Name, wspCfg.Status)
+				}
+				return nil
+			}
+
+			var wspName string
+			if len(args) > 0 {
+				wspName = args[0]
+			} else {
+				wspCfg, err := workspace.MatchDir(cfg, dir)
+				if err != nil {
+					return err
+				}
+				if wspCfg == nil {
+					return fmt.Errorf("%w: '%s'", ErrNoWorkspaceForDir, dir)
+				}
+				wspName = wspCfg.Name
+			}
+
+			plan, err := workspacePlan(cfg, wspName)
+			if err != nil {
+				return err
+			}
+
+			env := maps.Clone(plan.Env)
+			env["ZEST_WORKSPACE"] = wspName
+			out, err := shellhook.Exports(shell, env)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), out)
+			return nil
+		},
+	}
+
+	envCmd.Flags().StringVar(&shell, "shell", "bash", "Shell to write the exports for: bash, zsh or fish")
+	envCmd.Flags().StringVar(&dir, "dir", "", "Directory to find the workspace for (default is the current directory)")
+	envCmd.Flags().BoolVar(&which, "which", false, "Print the workspace of the directory and its status")
+	return envCmd
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// execCmd represents the exec command
+func NewExecCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var execCmd = &cobra.Command{
+		Use:   "exec [workspace-name] -- [command...]",
+		Short: "Run a command inside the environment of a workspace",
+		Long: `Runs any command in the workspace_dir of a workspace, with its environment:
+the global env, the workspace env and its env files, and ZEST_WORKSPACE set to
+the workspace name. An active workspace also keeps the --env it was launched
+with. zest exits with the exit code of the command.`,
+		Example: `  zest exec api -- go test ./...
+  zest exec api -- docker compose ps`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+				return fmt.Errorf("expected a workspace, then the command after --")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := workspacePlan(cfg, args[0])
+			if err != nil {
+				return err
+			}
+
+			c := exec.Command(args[1], args[2:]...)
+			c.Stdin, c.Stdout, c.Stderr = cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr()
+			return runInWorkspace(cmd, c, plan, args[0])
+		},
+	}
+
+	return execCmd
+}
+
+// runInWorkspace runs c in the directory and environment of the workspace,
+// passing its exit code on.
+func runInWorkspace(cmd *cobra.Command, c *exec.Cmd, plan *launch.Plan, wspName string) error {
+	c.Dir = plan.WorkingDir
+	c.Env = plan.Environ(map[string]string{"ZEST_WORKSPACE": wspName})
+
+	err := c.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		cmd.SilenceUsage = true
+		return &ExitCodeError{Code: exitErr.ExitCode(), Err: fmt.Errorf("%s exited with code %d", c.Args[0], exitErr.ExitCode())}
+	}
+	return err
+}
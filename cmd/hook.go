@@ -0,0 +1,82 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AVAniketh0905/zest/internal/shellhook"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// hookCmd represents the hook command
+func NewHookCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var (
+		noPrompt bool
+		onEnter  string
+	)
+
+	var hookCmd = &cobra.Command{
+		Use:   "hook [bash|zsh|fish]",
+		Short: "Print the shell integration of zest",
+		Long: `Prints shell code that shows the workspace of the current directory in the
+prompt and reacts when you cd into the workspace_dir of a workspace. Nested
+workspace_dirs go to the workspace with the longest one.
+
+What happens on entering a workspace is set with --on-enter:
+
+  off     only track the workspace for the prompt
+  ask     suggest 'zest launch' if the workspace is not active (default)
+  env     export the workspace env into the shell, like 'zest env'
+  launch  launch the workspace if it is not active
+
+Exports stay in the shell after you leave the directory. The zest commands run
+on entering keep their errors out of the shell, a failed launch is reported
+with a single line.`,
+		Example: `  # ~/.bashrc
+  eval "$(zest hook bash)"
+
+  # ~/.zshrc
+  eval "$(zest hook zsh --on-enter env)"
+
+  # ~/.config/fish/config.fish
+  zest hook fish --no-prompt | source`,
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: shellhook.Shells,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			script, err := shellhook.Script(args[0], shellhook.Options{
+				Prompt:  !noPrompt,
+				OnEnter: onEnter,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), script)
+			return nil
+		},
+	}
+
+	hookCmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "Leave the prompt as it is")
+	hookCmd.Flags().StringVar(&onEnter, "on-enter", "ask", "What to do on entering a workspace: off, ask, env or launch")
+	return hookCmd
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+func NewImportCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var importCmd = &cobra.Command{
+		Use:   "import <file.code-workspace>",
+		Short: "Create a workspace from a VS Code .code-workspace file",
+		Long: `Creates a workspace from a VS Code .code-workspace file. The workspace opens the
+folders of the file in VS Code, with its settings and recommended extensions,
+and its workspace_dir is the first folder. Relative folders are resolved
+against the directory of the file, remote folders are left out.
+
+The workspace is named after the file unless --name is given.`,
+		Example: `  zest import ~/src/platform.code-workspace
+  zest import platform.code-workspace --name platform --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+
+			name, err = workspace.ImportCodeWorkspace(cfg, args[0], name, force)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Workspace '%s' imported from %s.\n", name, args[0])
+			return nil
+		},
+	}
+
+	importCmd.Flags().String("name", "", "Name of the workspace, defaults to the name of the file")
+	importCmd.Flags().BoolP("force", "f", false, "Overwrite the workspace if it exists")
+	return importCmd
+}
@@ -22,8 +22,12 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"strings"
 
+	"github.com/AVAniketh0905/zest/internal/templates"
 	"github.com/AVAniketh0905/zest/internal/utils"
 	"github.com/AVAniketh0905/zest/internal/workspace"
 	"github.com/spf13/cobra"
@@ -37,12 +41,18 @@ func NewInitCmd(cfg *utils.ZestConfig) *cobra.Command {
 		Long: `Initializes a new workspace with the given name, setting up directories
 and optionally applying a template for scaffolding.
 
+Templates are looked up in ~/.zest/templates first and then among the built-in
+ones (go-dev, web-dev, research-with-pdfs, writing). Template variables are
+passed with --set key=value, any required variable left without a value is
+prompted for. Use 'zest template show <name>' to see the variables of a template.
+
 Workspaces are isolated environments used for organizing different contexts like
 work, personal, or learning projects.
 
 Use --force to overwrite an existing workspace.`,
 		Example: `  zest init work
-  zest init work --template dev-template
+  zest init api --template go-dev --set repo=~/src/api
+  zest init site -t web-dev --set repo=~/src/site --set url=http://localhost:5173
   zest init work --force
   zest init personal`,
 		Args: cobra.ExactArgs(1),
@@ -57,6 +67,13 @@ Use --force to overwrite an existing workspace.`,
 			if err != nil {
 				return err
 			}
+			sets, err := cmd.Flags().GetStringArray("set")
+			if err != nil {
+				return err
+			}
+			if len(sets) > 0 && template == "" {
+				return fmt.Errorf("--set can only be used with --template")
+			}
 
 			// Display user-facing info
 			fmt.Fprintf(cmd.OutOrStdout(), "Initializing workspace '%s'...\n", wspName)
@@ -68,8 +85,18 @@ Use --force to overwrite an existing workspace.`,
 				fmt.Fprintf(cmd.OutOrStdout(), "Using template: %s\n", template)
 			}
 
-			if err := workspace.Init(cfg, wspName, template, force); err != nil {
-				return fmt.Errorf("failed to initialize workspace '%s': %w", wspName, err)
+			if template == "" {
+				if err := workspace.Init(cfg, wspName, template, force); err != nil {
+					return fmt.Errorf("failed to initialize workspace '%s': %w", wspName, err)
+				}
+			} else {
+				spec, err := renderTemplate(cmd, cfg, template, wspName, sets)
+				if err != nil {
+					return err
+				}
+				if err := workspace.InitWithSpec(cfg, wspName, template, spec, force); err != nil {
+					return fmt.Errorf("failed to initialize workspace '%s': %w", wspName, err)
+				}
 			}
 
 			fmt.Fprintf(cmd.OutOrStdout(), "Workspace '%s' initialized successfully.\n", wspName)
@@ -80,6 +107,74 @@ Use --force to overwrite an existing workspace.`,
 	// Optional Flags
 	initCmd.Flags().StringP("template", "t", "", "Template to use for workspace scaffolding")
 	initCmd.Flags().BoolP("force", "f", false, "Force initialization even if workspace already exists")
+	initCmd.Flags().StringArray("set", nil, "Set a template variable (key=value), can be repeated")
 
 	return initCmd
 }
+
+// renderTemplate fills the variables of the named template from --set values,
+// defaults and, for anything still missing, a prompt on stdin.
+func renderTemplate(cmd *cobra.Command, cfg *utils.ZestConfig, name, wspName string, sets []string) ([]byte, error) {
+	tmpl, err := templates.Load(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := parseVars(sets)
+	if err != nil {
+		return nil, err
+	}
+	for key := range vars {
+		if _, ok := tmpl.Variable(key); !ok {
+			return nil, fmt.Errorf("template '%s' has no variable '%s'", name, key)
+		}
+	}
+
+	var in *bufio.Reader
+	for _, v := range tmpl.Missing(vars) {
+		if in == nil {
+			in = bufio.NewReader(cmd.InOrStdin())
+		}
+		val, err := promptVar(cmd.OutOrStdout(), in, v)
+		if err != nil {
+			return nil, err
+		}
+		vars[v.Name] = val
+	}
+
+	vars["name"] = wspName
+	return tmpl.Render(vars)
+}
+
+// parseVars turns key=value pairs into a map.
+func parseVars(sets []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, set := range sets {
+		key, val, ok := strings.Cut(set, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set '%s', expected key=value", set)
+		}
+		vars[key] = val
+	}
+	return vars, nil
+}
+
+// promptVar asks for the value of a template variable until one is given.
+func promptVar(w io.Writer, in *bufio.Reader, v templates.Variable) (string, error) {
+	for {
+		if v.Description != "" {
+			fmt.Fprintf(w, "%s (%s): ", v.Name, v.Description)
+		} else {
+			fmt.Fprintf(w, "%s: ", v.Name)
+		}
+
+		line, err := in.ReadString('\n')
+		if val := strings.TrimSpace(line); val != "" {
+			return val, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("no value given for template variable '%s', use --set %s=<value>", v.Name, v.Name)
+		}
+	}
+}
@@ -22,8 +22,15 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/AVAniketh0905/zest/internal/launch"
 	"github.com/AVAniketh0905/zest/internal/utils"
@@ -32,10 +39,15 @@ import (
 )
 
 type LaunchOptions struct {
-	DryRun bool
-	Detach bool
-	Env    map[string]string
-	Force  bool
+	launch.Options // saved in the runtime, see `zest restart`
+
+	DryRun  bool
+	Force   bool
+	Add     []string // apps to start in an already active workspace
+	Apps    []string // exact apps to start instead of Filter, set by `zest restart`
+	Stopped []string // apps stopped with `zest app stop`, kept stopped by `zest restart`
+
+	AllowDirty bool // switch the git branch even if the checkout has changes
 }
 
 // launchCmd represents the launch command
@@ -45,16 +57,54 @@ func NewLaunchCmd(cfg *utils.ZestConfig) *cobra.Command {
 		Short: "Launch a workspace",
 		Long: `Launches the specified workspace, initializing its runtime state and executing
 its startup plan. You can use --env to inject environment variables, --dry-run to preview,
-or --detach to run in background.`,
+or --detach to run in background.
+
+Use --only, --skip and --tags to launch a subset of the apps. Apps are named by
+their id, their type (e.g. vscode) or their role (editor, terminal, browser,
+viewer). --add starts more apps in a workspace that is already active, apps
+that are running are left alone.
+
+--watch keeps zest running after the launch and applies every change to the
+workspace file, its includes and its env files, like 'zest apply', until zest
+is interrupted or terminated. Invalid edits are reported and the running apps
+are left as they are. A --detach launch returns right away and never watches,
+even when launch.watch is set.
+
+A workspace with a git block is put on its branch before the apps start. zest
+refuses to switch the branch of a checkout with uncommitted changes, unless
+--allow-dirty is given.
+
+Without a name, the .zest.yaml of the current directory or its closest parent
+is registered as a workspace and launched. It has to be trusted with
+'zest allow' first.`,
 		Example: `  zest launch work
+  zest launch
   zest launch work --detach
   zest launch personal --dry-run
   zest launch work --env MODE=dev
   zest launch work --force
+  zest launch work --parallel 4
+  zest launch work --profile review
+  zest launch work --only editor,terminal
+  zest launch work --skip browser
+  zest launch work --tags frontend
+  zest launch work --add browser
+  zest launch work --watch
   zest launch personal --dry-run --env MODE=test`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			wspName := args[0]
+			var wspName string
+			if len(args) > 0 {
+				wspName = args[0]
+			} else {
+				path, err := findProjectFile("")
+				if err != nil {
+					return err
+				}
+				if wspName, err = workspace.RegisterProject(cfg, path); err != nil {
+					return fmt.Errorf("failed to register %s: %w", path, err)
+				}
+			}
 
 			dryRun, err := cmd.Flags().GetBool("dry-run")
 			if err != nil {
@@ -72,22 +122,78 @@ or --detach to run in background.`,
 			if err != nil {
 				return err
 			}
+			profile, err := cmd.Flags().GetString("profile")
+			if err != nil {
+				return err
+			}
+
+			only, err := cmd.Flags().GetStringSlice("only")
+			if err != nil {
+				return err
+			}
+			skip, err := cmd.Flags().GetStringSlice("skip")
+			if err != nil {
+				return err
+			}
+			tags, err := cmd.Flags().GetStringSlice("tags")
+			if err != nil {
+				return err
+			}
+			add, err := cmd.Flags().GetStringSlice("add")
+			if err != nil {
+				return err
+			}
+			allowDirty, err := cmd.Flags().GetBool("allow-dirty")
+			if err != nil {
+				return err
+			}
+			if len(add) > 0 && (len(only) > 0 || force) {
+				return fmt.Errorf("--add can't be combined with --only or --force")
+			}
+			if detach && cmd.Flags().Changed("watch") && cfg.Settings.Launch.Watch {
+				return fmt.Errorf("--watch can't be combined with --detach")
+			}
 
 			opts := LaunchOptions{
-				DryRun: dryRun,
-				Detach: detach,
-				Env:    env,
-				Force:  force,
+				Options: launch.Options{
+					Profile: profile,
+					Env:     env,
+					Filter:  launch.AppFilter{Only: only, Skip: skip, Tags: tags},
+					Detach:  detach,
+				},
+				DryRun:     dryRun,
+				Force:      force,
+				Add:        add,
+				AllowDirty: allowDirty,
 			}
 
-			return launchWorkspace(cmd.OutOrStdout(), cfg, opts, wspName)
+			if err := launchWorkspace(cmd.OutOrStdout(), cfg, opts, wspName); err != nil {
+				return err
+			}
+			if !cfg.Settings.Launch.Watch || dryRun || detach {
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return watchWorkspace(ctx, cmd.OutOrStdout(), cfg, wspName)
 		},
 	}
 
 	launchCmd.Flags().Bool("dry-run", false, "Validate config and simulate launch without executing")
 	launchCmd.Flags().BoolP("detach", "d", false, "Run workspace in background")
 	launchCmd.Flags().StringToString("env", nil, "Set or override environment variables (e.g. --env KEY=VALUE)")
+	launchCmd.Flags().StringP("profile", "p", "", "Profile of the workspace to launch (e.g. minimal, review)")
 	launchCmd.Flags().BoolP("force", "f", false, "Force launch even if workspace is active")
+	launchCmd.Flags().StringSlice("only", nil, "Launch only these apps (ids, types or roles)")
+	launchCmd.Flags().StringSlice("skip", nil, "Don't launch these apps (ids, types or roles)")
+	launchCmd.Flags().StringSlice("tags", nil, "Launch only apps with one of these tags")
+	launchCmd.Flags().StringSlice("add", nil, "Start these apps in an already active workspace")
+	launchCmd.Flags().Bool("allow-dirty", false, "Switch the git branch even if the checkout has uncommitted changes")
+	launchCmd.Flags().Bool("watch", false, "Apply changes to the workspace files until interrupted (default from launch.watch)")
+	launchCmd.Flags().Int("parallel", 0, "Number of apps started at the same time (default from launch.parallelism)")
+	bindSetting(launchCmd, "watch", "launch.watch")
+	bindSetting(launchCmd, "parallel", "launch.parallelism")
 
 	return launchCmd
 }
@@ -105,6 +211,14 @@ func launchWorkspace(w io.Writer, cfg *utils.ZestConfig, opts LaunchOptions, wsp
 		return fmt.Errorf("workspace '%s' does not exist", wspName)
 	}
 
+	if len(opts.Add) > 0 {
+		if wspCfg.Status == workspace.Active {
+			return addApps(w, cfg, opts, wspName)
+		}
+		// nothing is running yet, --add is the same as --only
+		opts.Filter.Only = opts.Add
+	}
+
 	// Block if already active and not forcing
 	if wspCfg.Status == workspace.Active && !opts.Force {
 		fmt.Fprintf(w, "Workspace '%s' is already active. Use --force to re-launch.\n", wspName)
@@ -112,10 +226,15 @@ func launchWorkspace(w io.Writer, cfg *utils.ZestConfig, opts LaunchOptions, wsp
 	}
 
 	// Build launch plan
-	plan, err := launch.NewLaunchPlan(cfg, wspName)
+	plan, err := launch.NewLaunchPlan(cfg, wspName, opts.Profile)
 	if err != nil {
 		return fmt.Errorf("failed to create launch plan for '%s': %w", wspName, err)
 	}
+	if opts.Apps != nil {
+		plan.Keep(opts.Apps)
+	} else if err := plan.Filter(opts.Filter); err != nil {
+		return err
+	}
 
 	if len(opts.Env) > 0 {
 		fmt.Fprintf(w, "Applying environment variables: %v\n", opts.Env)
@@ -131,28 +250,207 @@ func launchWorkspace(w io.Writer, cfg *utils.ZestConfig, opts LaunchOptions, wsp
 	if opts.DryRun {
 		fmt.Fprintln(w, "[zest] Dry-run mode enabled. Launch plan preview:")
 		fmt.Fprintln(w, plan.Summary())
+		if plan.Inherits() {
+			fmt.Fprintln(w, "Flattened spec:")
+			fmt.Fprint(w, string(plan.Spec))
+		}
 		return nil
 	}
 
+	closeLog, err := attachSessionLog(cfg, wspName, plan, "launch")
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	// nothing runs when an app can't start on this system
+	if err := plan.CheckSupported(); err != nil {
+		return fmt.Errorf("failed to launch workspace '%s': %w", wspName, err)
+	}
+	if err := plan.CheckoutGit(w, opts.AllowDirty); err != nil {
+		return fmt.Errorf("failed to launch workspace '%s': %w", wspName, err)
+	}
+
+	if len(plan.Hooks.PreLaunch) > 0 {
+		fmt.Fprintf(w, "Running %d pre_launch hook(s)...\n", len(plan.Hooks.PreLaunch))
+	}
+	if err := plan.RunHooks(launch.PreLaunch); err != nil {
+		return fmt.Errorf("failed to launch workspace '%s': %w", wspName, err)
+	}
+
+	// A forced relaunch replaces the running session instead of orphaning it
+	if wspCfg.Status == workspace.Active {
+		if err := stopSession(w, cfg, wspName); err != nil {
+			return err
+		}
+	}
+
 	// Start execution of the plan
 	fmt.Fprintln(w, "Starting launch...")
-	if err := plan.Start(); err != nil {
-		return fmt.Errorf("failed to launch workspace '%s': %w", wspName, err)
+	startErr := plan.Start()
+	if startErr != nil && !errors.Is(startErr, launch.ErrPostLaunch) {
+		// the workspace stays inactive, so nothing it started may keep running
+		stopStarted(w, cfg, plan)
+		return fmt.Errorf("failed to launch workspace '%s': %w", wspName, startErr)
 	}
 
 	// Update and persist runtime state
 	wspRt.Update(plan)
+	wspRt.Stopped = opts.Stopped
+	wspRt.Launch = opts.Options
 	if err := wspRt.Save(); err != nil {
+		stopStarted(w, cfg, plan)
 		return fmt.Errorf("failed to save runtime state: %w", err)
 	}
 
 	// Mark workspace as active
-	wspCfg.Status = workspace.Active
-	wspReg.Update(wspCfg)
-	if err := wspReg.Save(); err != nil {
+	err = wspReg.Transact(func() error {
+		entry, ok := wspReg.GetCfg(wspName)
+		if !ok {
+			return workspace.ErrWorkspaceNotExists
+		}
+		entry.Status = workspace.Active
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update registry: %w", err)
 	}
 
+	if len(plan.Hooks.PostLaunch) > 0 {
+		fmt.Fprintf(w, "Running %d post_launch hook(s)...\n", len(plan.Hooks.PostLaunch))
+	}
+	if err := plan.RunHooks(launch.PostLaunch); err != nil {
+		return fmt.Errorf("workspace '%s' launched, but %w", wspName, err)
+	}
+	// the apps whose post_launch hooks failed are running and recorded
+	if startErr != nil {
+		return fmt.Errorf("workspace '%s' launched, but %w", wspName, startErr)
+	}
+
 	fmt.Fprintf(w, "Workspace '%s' launched successfully.\n", wspName)
 	return nil
 }
+
+// stopSession stops the processes recorded for an active workspace.
+func stopSession(w io.Writer, cfg *utils.ZestConfig, wspName string) error {
+	oldRt, err := workspace.NewWspRuntime(cfg, wspName)
+	if err != nil {
+		return fmt.Errorf("failed to initialize runtime for '%s': %w", wspName, err)
+	}
+	if err := oldRt.Load(); err != nil {
+		return fmt.Errorf("failed to load runtime for '%s': %w", wspName, err)
+	}
+
+	pids := flatten(oldRt.PIDs)
+	if len(pids) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(w, "Stopping the running session of '%s'...\n", wspName)
+	errs := utils.Stop(pids, cfg.Settings.Close.GracePeriod)
+	for _, pid := range pids {
+		if err, ok := errs[pid]; ok {
+			fmt.Fprintf(w, "Warning: failed to kill PID %d for workspace '%s': %v\n", pid, wspName, err)
+		}
+	}
+	return nil
+}
+
+// stopStarted stops the apps of a launch that failed part way.
+func stopStarted(w io.Writer, cfg *utils.ZestConfig, plan *launch.Plan) {
+	pids := flatten(plan.GetPIDs())
+	if len(pids) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Stopping the apps that did start...\n")
+	errs := utils.Stop(pids, cfg.Settings.Close.GracePeriod)
+	for _, pid := range pids {
+		if err, ok := errs[pid]; ok {
+			fmt.Fprintf(w, "Warning: failed to kill PID %d for workspace '%s': %v\n", pid, plan.Name, err)
+		}
+	}
+}
+
+// addApps starts the apps named by --add in an active workspace, skipping
+// the ones its runtime already records as running.
+func addApps(w io.Writer, cfg *utils.ZestConfig, opts LaunchOptions, wspName string) error {
+	wspRt, err := workspace.NewWspRuntime(cfg, wspName)
+	if err != nil {
+		return fmt.Errorf("failed to initialize runtime for '%s': %w", wspName, err)
+	}
+	if err := wspRt.Load(); err != nil {
+		return fmt.Errorf("failed to load runtime state: %w", err)
+	}
+
+	// keep the apps consistent with what is already running
+	profile := opts.Profile
+	if profile == "" {
+		profile = wspRt.Profile
+	}
+
+	plan, err := launch.NewLaunchPlan(cfg, wspName, profile)
+	if err != nil {
+		return fmt.Errorf("failed to create launch plan for '%s': %w", wspName, err)
+	}
+	opts.Filter.Only = opts.Add
+	if err := plan.Filter(opts.Filter); err != nil {
+		return err
+	}
+	for _, id := range plan.Without(wspRt.Apps) {
+		fmt.Fprintf(w, "App '%s' is already running.\n", id)
+	}
+
+	// variables given at launch still apply, --env wins over them
+	env := map[string]string{}
+	maps.Copy(env, wspRt.Launch.Env)
+	maps.Copy(env, opts.Env)
+	if len(env) > 0 {
+		fmt.Fprintf(w, "Applying environment variables: %v\n", env)
+		plan.ApplyEnv(env)
+	}
+
+	if len(plan.Apps) == 0 {
+		fmt.Fprintln(w, "Nothing to add.")
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Fprintln(w, "[zest] Dry-run mode enabled. Apps to add:")
+		fmt.Fprintln(w, plan.Summary())
+		return nil
+	}
+
+	closeLog, err := attachSessionLog(cfg, wspName, plan, "add "+strings.Join(plan.GetAppIDs(), ", "))
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	err = wspRt.Transact(func() error {
+		// another zest may have started some of them meanwhile
+		plan.Without(wspRt.Apps)
+
+		fmt.Fprintf(w, "Adding %s to workspace '%s'...\n", strings.Join(plan.GetAppIDs(), ", "), wspName)
+		startErr := plan.Start()
+
+		// record whatever did start, even if another app failed
+		if startErr != nil {
+			started := []launch.AppSpec{}
+			for _, app := range plan.Apps {
+				if len(app.GetPIDs()) > 0 {
+					started = append(started, app)
+				}
+			}
+			plan.Apps = started
+		}
+		wspRt.Append(plan)
+		return startErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add apps to workspace '%s': %w", wspName, err)
+	}
+
+	fmt.Fprintf(w, "Apps added to workspace '%s'.\n", wspName)
+	return nil
+}
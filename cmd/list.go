@@ -56,15 +56,9 @@ and JSON output for automation or scripting.`,
   zest list --filter active
   zest list --sort last_used`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			filter, err := cmd.Flags().GetString("filter")
-			if err != nil {
-				return err
-			}
-			sortBy, err := cmd.Flags().GetString("sort")
-			if err != nil {
-				return err
-			}
-			isJSON, err := cmd.Flags().GetBool("json")
+			filter := cfg.Settings.List.Filter
+			sortBy := cfg.Settings.List.Sort
+			isJSON, err := jsonOutput(cmd, cfg)
 			if err != nil {
 				return err
 			}
@@ -90,8 +84,10 @@ and JSON output for automation or scripting.`,
 
 	// Flags
 	listCmd.Flags().Bool("json", false, "Output in JSON format")
-	listCmd.Flags().String("filter", "all", "Filter by status: active, inactive, all")
-	listCmd.Flags().String("sort", "name", "Sort by: name, last_used, status")
+	listCmd.Flags().String("filter", "", "Filter by status: active, inactive, all (default from list.filter)")
+	listCmd.Flags().String("sort", "", "Sort by: name, last_used, status (default from list.sort)")
+	bindSetting(listCmd, "filter", "list.filter")
+	bindSetting(listCmd, "sort", "list.sort")
 
 	return listCmd
 }
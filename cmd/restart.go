@@ -0,0 +1,172 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// restartCmd represents the restart command
+func NewRestartCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var restartCmd = &cobra.Command{
+		Use:   "restart [workspace-name] [app-id...]",
+		Short: "Restart an active workspace or some of its apps",
+		Long: `Closes and relaunches an active workspace with the options it was launched
+with: its profile, --env variables and the apps that are running. Name apps to
+restart only those, an app that isn't running is started.
+
+Use --rolling for workspaces that run long-lived services, apps are then
+restarted one at a time and each must be ready before the next one is
+stopped. An app is ready once its processes run and, when it has a ready
+check, its port accepts connections or its url answers.`,
+		Example: `  zest restart work
+  zest restart work server
+  zest restart work --rolling
+  zest restart work api worker --rolling --grace 10s`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rolling, err := cmd.Flags().GetBool("rolling")
+			if err != nil {
+				return err
+			}
+			return restartWorkspace(cmd.OutOrStdout(), cfg, args[0], args[1:], rolling)
+		},
+	}
+
+	restartCmd.Flags().Bool("rolling", false, "Restart apps one at a time, waiting for each to be ready")
+	restartCmd.Flags().Duration("grace", 0, "Time apps get to exit before they are killed (default from close.grace_period)")
+	bindSetting(restartCmd, "grace", "close.grace_period")
+	return restartCmd
+}
+
+func restartWorkspace(w io.Writer, cfg *utils.ZestConfig, wspName string, ids []string, rolling bool) error {
+	_, wspRt, err := loadWorkspaceState(cfg, wspName)
+	if err != nil {
+		return err
+	}
+	if wspRt == nil {
+		return fmt.Errorf("%w: '%s', launch it first", workspace.ErrWorkspaceIsInactive, wspName)
+	}
+	tracked := len(wspRt.Apps) == len(wspRt.PIDs)
+
+	if len(ids) == 0 && !rolling {
+		opts := LaunchOptions{Options: wspRt.Launch, Force: true}
+		if tracked {
+			// apps added or stopped since the launch are restarted as they are now
+			opts.Apps = append([]string{}, wspRt.Apps...)
+			opts.Stopped = wspRt.Stopped
+		}
+		fmt.Fprintf(w, "Restarting workspace '%s'...\n", wspName)
+		return launchWorkspace(w, cfg, opts, wspName)
+	}
+
+	if !tracked {
+		return workspace.ErrUntrackedApps
+	}
+	if len(ids) == 0 {
+		ids = append([]string{}, wspRt.Apps...)
+	}
+
+	plan, err := sessionPlan(cfg, wspRt)
+	if err != nil {
+		return err
+	}
+	apps := []launch.AppSpec{}
+	for _, id := range ids {
+		app, ok := plan.App(id)
+		if !ok {
+			return fmt.Errorf("%w '%s' in workspace '%s', available: %s",
+				launch.ErrNoMatchingApp, id, wspName, strings.Join(plan.GetAppIDs(), ", "))
+		}
+		apps = append(apps, app)
+	}
+
+	closeLog, err := attachSessionLog(cfg, wspName, plan, "restart")
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	err = wspRt.Transact(func() error {
+		if rolling {
+			return rollApps(w, cfg, wspRt, plan, apps)
+		}
+		return bounceApps(w, cfg, wspRt, plan, apps)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart workspace '%s': %w", wspName, err)
+	}
+
+	fmt.Fprintf(w, "Workspace '%s' restarted.\n", wspName)
+	return nil
+}
+
+// bounceApps stops all apps, then starts them again.
+func bounceApps(w io.Writer, cfg *utils.ZestConfig, wspRt *workspace.WspRuntime, plan *launch.Plan, apps []launch.AppSpec) error {
+	for _, app := range apps {
+		if err := stopIfRunning(w, cfg, wspRt, plan, app.Meta().ID); err != nil {
+			return err
+		}
+	}
+	for _, app := range apps {
+		if err := runApp(w, wspRt, plan, app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollApps restarts the apps one by one, stopping at the first app that
+// doesn't come back.
+func rollApps(w io.Writer, cfg *utils.ZestConfig, wspRt *workspace.WspRuntime, plan *launch.Plan, apps []launch.AppSpec) error {
+	for i, app := range apps {
+		id := app.Meta().ID
+		fmt.Fprintf(w, "[%d/%d] Restarting app '%s'...\n", i+1, len(apps), id)
+
+		if err := stopIfRunning(w, cfg, wspRt, plan, id); err != nil {
+			return err
+		}
+		if err := runApp(w, wspRt, plan, app); err != nil {
+			return err
+		}
+		if err := launch.WaitReady(app); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "App '%s' is ready.\n", id)
+	}
+	return nil
+}
+
+func stopIfRunning(w io.Writer, cfg *utils.ZestConfig, wspRt *workspace.WspRuntime, plan *launch.Plan, id string) error {
+	if wspRt.FindApp(id) < 0 {
+		fmt.Fprintf(w, "App '%s' is not running, starting it.\n", id)
+		return nil
+	}
+	return killApp(w, wspRt, plan, id, cfg.Settings.Close.GracePeriod)
+}
@@ -22,12 +22,16 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/AVAniketh0905/zest/internal/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -44,6 +48,10 @@ var cfgFile string
 // This is called by main.main(). It only needs to happen once to the RootCmd.
 func Execute() {
 	err := RootCmd.Execute()
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.Code)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
@@ -58,6 +66,22 @@ func addCommands(rootCmd *cobra.Command, cfg *utils.ZestConfig) {
 	rootCmd.AddCommand(NewLaunchCmd(cfg))
 	rootCmd.AddCommand(NewCloseCmd(cfg))
 	rootCmd.AddCommand(NewDeleteCmd(cfg))
+	rootCmd.AddCommand(NewDoctorCmd(cfg))
+	rootCmd.AddCommand(NewConfigCmd(cfg))
+	rootCmd.AddCommand(NewTemplateCmd(cfg))
+	rootCmd.AddCommand(NewAppCmd(cfg))
+	rootCmd.AddCommand(NewRestartCmd(cfg))
+	rootCmd.AddCommand(NewSwitchCmd(cfg))
+	rootCmd.AddCommand(NewDiffCmd(cfg))
+	rootCmd.AddCommand(NewApplyCmd(cfg))
+	rootCmd.AddCommand(NewRunCmd(cfg))
+	rootCmd.AddCommand(NewExecCmd(cfg))
+	rootCmd.AddCommand(NewShellCmd(cfg))
+	rootCmd.AddCommand(NewEnvCmd(cfg))
+	rootCmd.AddCommand(NewHookCmd(cfg))
+	rootCmd.AddCommand(NewAllowCmd(cfg))
+	rootCmd.AddCommand(NewDiscoverCmd(cfg))
+	rootCmd.AddCommand(NewImportCmd(cfg))
 }
 
 func NewRootCmd(cfg *utils.ZestConfig) *cobra.Command {
@@ -70,8 +94,15 @@ interface across platforms.
 You can create and switch between isolated workspaces such as work, personal, or learning.
 Each workspace can be initialized with custom templates for different use cases.`,
 		Version: VERSION,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			initConfig(cfg, cfgFile)
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			err := initConfig(cfg, cfgFile, cmd)
+			if err != nil && isConfigCmd(cmd) {
+				// a broken zest.yaml must stay fixable through `zest config`
+				fmt.Fprintln(cmd.ErrOrStderr(), "Warning:", err)
+				cfg.Settings = utils.DefaultSettings()
+				return nil
+			}
+			return err
 		},
 	}
 
@@ -80,6 +111,9 @@ Each workspace can be initialized with custom templates for different use cases.
 	// custom path to zest directory
 	rootCmd.PersistentFlags().StringVar(&cfg.ZestDir, "custom", "", "custom zest directory (default is $HOME/.zest)")
 
+	rootCmd.PersistentFlags().String("output", "", "Output format of every command: table or json (default from output)")
+	bindSetting(rootCmd, "output", "output")
+
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 
 	// Version
@@ -97,39 +131,184 @@ func init() {
 	RootCmd = NewRootCmd(cfg)
 }
 
-// initConfig reads in config file and ENV variables if set.
-func initConfig(cfg *utils.ZestConfig, cfgFile string) {
+// settingAnnotation marks the flags that override a global setting.
+const settingAnnotation = "zest_setting"
+
+// bindSetting lets the flag name of cmd override the global setting key when
+// it is given. Only the command that owns the flag binds it, so a flag of the
+// same name elsewhere keeps its own meaning. A persistent flag binds the
+// setting for every subcommand as well.
+func bindSetting(cmd *cobra.Command, name, key string) {
+	flags := cmd.Flags()
+	if flags.Lookup(name) == nil {
+		flags = cmd.PersistentFlags()
+	}
+	if err := flags.SetAnnotation(name, settingAnnotation, []string{key}); err != nil {
+		panic(err)
+	}
+}
+
+// initConfig reads in config file, ENV variables and flags of the running command if set.
+func initConfig(cfg *utils.ZestConfig, cfgFile string, cmd *cobra.Command) error {
+	v, err := newViper(cfg, cfgFile, cmd)
+	if err != nil {
+		return err
+	}
+	settings, err := decodeSettings(v, cfg.ConfigFile)
+	if err != nil {
+		return err
+	}
+	cfg.Settings = settings
+
+	return nil
+}
+
+// newViper layers defaults, the config file, ZEST_* environment variables and
+// the flags of cmd, and records the config file in use on cfg.
+func newViper(cfg *utils.ZestConfig, cfgFile string, cmd *cobra.Command) (*viper.Viper, error) {
+	v := viper.New()
+
+	// Zest configuration and state directory structure:
+	//
+	// $HOME/.zest/
+	// ├── zest.yaml                        // Global configuration file (user editable)
+	// ├── workspaces/                      // Per-workspace configuration (user editable)
+	// │   ├── [name of wsp].yaml           // Config for each workspace
+	// ├── templates/                       // User templates for `zest init --template` (user editable)
+	// │   ├── [name of template].yaml      // Overrides a built-in template of the same name
+	// ├── worktrees/                       // Git worktrees added for `git.worktree`
+	// │   ├── [name of wsp]/[branch]/      // Worktree of a branch of each workspace
+	// ├── state/                           // Internal state files (NOT user editable)
+	// │   ├── workspaces.json              // Overall state of all workspaces
+	// │   ├── allowed.json                 // Project files trusted with `zest allow`
+	// │   ├── code-workspaces/             // .code-workspace files generated for vscode apps
+	// │   ├── other_future_cmds.json       // Additional future commands/state
+	// │   ├── logs/                        // Session logs, e.g. the output of hooks
+	// │   │   ├── [name of wsp].log        // Log of each workspace
+	// │   └── workspaces/                  // Per-workspace state files
+	// │       ├── [name of wsp].json       // State for each workspace
+	// Check for necessary directories.
+	if err := cfg.EnsureDirs(); err != nil {
+		return nil, err
+	}
+
 	if cfgFile != "" {
 		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
+		v.SetConfigFile(cfgFile)
 	} else {
-		// Find home directory.
-		cfgPath := cfg.RootDir()
-
-		// Zest configuration and state directory structure:
-		//
-		// $HOME/.zest/
-		// ├── zest.yaml                        // Global configuration file (user editable)
-		// ├── workspace/                       // Per-workspace configuration (user editable)
-		// │   ├── [name of wsp].yaml           // Config for each workspace
-		// ├── state/                           // Internal state files (NOT user editable)
-		// │   ├── workspace.json               // Overall state of all workspaces
-		// │   ├── other_future_cmds.json       // Additional future commands/state
-		// │   └── workspace/                   // Per-workspace state files
-		// │       ├── [name of wsp].json       // State for each workspace
-		// Check for necessary directories.
-		cobra.CheckErr(cfg.EnsureDirs())
-
 		// config path at $HOME/.zest/zest.yaml
-		viper.AddConfigPath(cfgPath)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName("zest")
+		v.AddConfigPath(cfg.RootDir())
+		v.SetConfigType("yaml")
+		v.SetConfigName("zest")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	for key, val := range settingDefaults() {
+		v.SetDefault(key, val)
+	}
+
+	// read in environment variables that match, e.g. ZEST_LAUNCH_PARALLELISM
+	v.SetEnvPrefix("zest")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	// flags given on the command line take precedence over everything else
+	var bindErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		for _, key := range f.Annotations[settingAnnotation] {
+			if err := v.BindPFlag(key, f); err != nil && bindErr == nil {
+				bindErr = err
+			}
+		}
+	})
+	if bindErr != nil {
+		return nil, bindErr
+	}
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	cfg.ConfigFile = ""
+	if err := v.ReadInConfig(); err == nil {
+		cfg.ConfigFile = v.ConfigFileUsed()
+	} else if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return v, nil
+}
+
+// decodeSettings converts the layered viper values into typed settings.
+func decodeSettings(v *viper.Viper, configFile string) (utils.Settings, error) {
+	settings := utils.Settings{}
+	if err := v.Unmarshal(&settings); err != nil {
+		return settings, fmt.Errorf("failed to decode config file: %w", err)
+	}
+	if configFile != "" {
+		env, err := readGlobalEnv(configFile)
+		if err != nil {
+			return settings, fmt.Errorf("failed to read env from config file: %w", err)
+		}
+		settings.Env = env
+	}
+	if err := settings.Validate(); err != nil {
+		return settings, fmt.Errorf("invalid config: %w", err)
+	}
+	return settings, nil
+}
+
+// readGlobalEnv reads the env section of the config file as written, since
+// viper lowercases map keys and environment variable names are case-sensitive.
+func readGlobalEnv(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := struct {
+		Env map[string]string `yaml:"env"`
+	}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Env == nil {
+		raw.Env = map[string]string{}
+	}
+	return raw.Env, nil
+}
+
+// settingDefaults returns the default of every global setting, keyed by its
+// path in zest.yaml. Registering each key lets ZEST_* variables override it.
+func settingDefaults() map[string]any {
+	d := utils.DefaultSettings()
+
+	return map[string]any{
+		"launch.parallelism": d.Launch.Parallelism,
+		"launch.watch":       d.Launch.Watch,
+		"close.grace_period": d.Close.GracePeriod,
+		"list.sort":          d.List.Sort,
+		"list.filter":        d.List.Filter,
+		"browser":            d.Browser,
+		"terminal":           d.Terminal,
+		"editor":             d.Editor,
+		"output":             d.Output,
+		"env":                d.Env,
+		"binaries":           d.Binaries,
+	}
+}
+
+// isConfigCmd reports whether cmd is `zest config` or one of its subcommands.
+func isConfigCmd(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "config" && c.Parent() != nil && !c.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonOutput reports whether a command should print JSON, falling back to
+// the configured output format when --json is not given.
+func jsonOutput(cmd *cobra.Command, cfg *utils.ZestConfig) (bool, error) {
+	if !cmd.Flags().Changed("json") {
+		return cfg.Settings.JSONOutput(), nil
 	}
+	return cmd.Flags().GetBool("json")
 }
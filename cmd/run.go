@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// ExitCodeError carries the exit code of a command run by zest, zest exits
+// with the same code.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string { return e.Err.Error() }
+func (e *ExitCodeError) Unwrap() error { return e.Err }
+
+// runCmd represents the run command
+func NewRunCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var runCmd = &cobra.Command{
+		Use:   "run [workspace-name] [task] [-- args...]",
+		Short: "Run a task of a workspace",
+		Long: `Runs a task from the tasks: section of a workspace file in its workspace_dir,
+with the environment of the workspace: the global env, the workspace env and
+its env files. An active workspace also keeps the profile and --env it was
+launched with.
+
+Tasks listed under deps run first. Arguments after -- are passed to the task,
+and zest exits with the exit code of the task.`,
+		Example: `  zest run api --list
+  zest run api migrate
+  zest run api test -- -run TestLogin -v`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			list, err := cmd.Flags().GetBool("list")
+			if err != nil {
+				return err
+			}
+			if list {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 && dash != 2 {
+				return fmt.Errorf("expected a workspace and a task before --, got %d argument(s)", dash)
+			}
+			if cmd.ArgsLenAtDash() < 0 && len(args) != 2 {
+				return fmt.Errorf("expected a workspace and a task, pass task arguments after --")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := cmd.Flags().GetBool("list")
+			if err != nil {
+				return err
+			}
+
+			plan, err := workspacePlan(cfg, args[0])
+			if err != nil {
+				return err
+			}
+
+			if list {
+				return listTasks(cmd.OutOrStdout(), plan)
+			}
+
+			tio := launch.TaskIO{Stdin: cmd.InOrStdin(), Stdout: cmd.OutOrStdout(), Stderr: cmd.ErrOrStderr()}
+			err = plan.RunTask(args[1], args[2:], tio)
+
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				cmd.SilenceUsage = true
+				return &ExitCodeError{Code: exitErr.ExitCode(), Err: err}
+			}
+			return err
+		},
+	}
+
+	runCmd.Flags().BoolP("list", "l", false, "List the tasks of the workspace")
+	return runCmd
+}
+
+// workspacePlan builds the plan that gives commands run inside a workspace
+// their directory and env. An active workspace uses its running session.
+func workspacePlan(cfg *utils.ZestConfig, wspName string) (*launch.Plan, error) {
+	_, wspRt, err := loadWorkspaceState(cfg, wspName)
+	if err != nil {
+		return nil, err
+	}
+	if wspRt != nil {
+		return sessionPlan(cfg, wspRt)
+	}
+
+	plan, err := launch.NewLaunchPlan(cfg, wspName, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create launch plan for '%s': %w", wspName, err)
+	}
+	return plan, nil
+}
+
+func listTasks(w io.Writer, plan *launch.Plan) error {
+	if len(plan.Tasks) == 0 {
+		fmt.Fprintf(w, "Workspace '%s' has no tasks.\n", plan.Name)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TASK\tDEPS\tDESCRIPTION")
+	for _, name := range plan.TaskNames() {
+		task := plan.Tasks[name]
+		deps := strings.Join(task.Deps, ", ")
+		if deps == "" {
+			deps = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, deps, task.Description)
+	}
+	return tw.Flush()
+}
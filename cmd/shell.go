@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// shellCmd represents the shell command
+func NewShellCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var shellCmd = &cobra.Command{
+		Use:   "shell [workspace-name]",
+		Short: "Open a shell inside the environment of a workspace",
+		Long: `Opens your $SHELL in the workspace_dir of a workspace, with the same
+environment as 'zest exec'. ZEST_WORKSPACE holds the workspace name, so a
+prompt can show it. Exit the shell to leave the workspace.`,
+		Example: `  zest shell api`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := workspacePlan(cfg, args[0])
+			if err != nil {
+				return err
+			}
+
+			if current := os.Getenv("ZEST_WORKSPACE"); current != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: already inside workspace '%s'.\n", current)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Entering workspace '%s', exit the shell to leave.\n", args[0])
+
+			c := exec.Command(userShell())
+			c.Stdin, c.Stdout, c.Stderr = cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr()
+			return runInWorkspace(cmd, c, plan, args[0])
+		},
+	}
+
+	return shellCmd
+}
+
+// userShell returns the login shell of the user, falling back to the
+// default shell of the system.
+func userShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	if runtime.GOOS == "windows" {
+		if comspec := os.Getenv("COMSPEC"); comspec != "" {
+			return comspec
+		}
+		return "cmd.exe"
+	}
+	return "/bin/sh"
+}
@@ -25,21 +25,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/AVAniketh0905/zest/internal/launch"
 	"github.com/AVAniketh0905/zest/internal/utils"
 	"github.com/AVAniketh0905/zest/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 type StatusReport struct {
-	Skipped   []string                `json:"skipped,omitempty"`
-	Inactive  []*workspace.WspConfig  `json:"inactive"`
-	Active    []*workspace.WspRuntime `json:"active"`
-	Timestamp string                  `json:"generated_at"`
+	Skipped   []string                     `json:"skipped,omitempty"`
+	Inactive  []*workspace.WspConfig       `json:"inactive"`
+	Active    []*workspace.WspRuntime      `json:"active"`
+	Git       map[string]*launch.GitStatus `json:"git,omitempty"` // with --verbose, keyed by workspace
+	Timestamp string                       `json:"generated_at"`
 }
 
 // statusCmd represents the status command
@@ -50,6 +54,8 @@ func NewStatusCmd(cfg *utils.ZestConfig) *cobra.Command {
 		Long: `Displays runtime information about one or all currently active workspaces.
 
 This includes application runtime status, process IDs, open ports, and other session details.
+With --verbose it also shows the branch of the git checkout of each workspace, how far it
+is ahead of or behind its upstream, and whether it has uncommitted changes.
 
 If no workspace is specified, status is shown for all.`,
 		Example: `  zest status
@@ -59,7 +65,7 @@ If no workspace is specified, status is shown for all.`,
   zest status --watch`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			jsonOut, err := cmd.Flags().GetBool("json")
+			jsonOut, err := jsonOutput(cmd, cfg)
 			if err != nil {
 				return err
 			}
@@ -97,7 +103,7 @@ If no workspace is specified, status is shown for all.`,
 	}
 
 	statusCmd.Flags().Bool("json", false, "Output in JSON format")
-	statusCmd.Flags().BoolP("verbose", "v", false, "Show detailed process/runtime info")
+	statusCmd.Flags().BoolP("verbose", "v", false, "Show detailed process/runtime info and git state")
 	statusCmd.Flags().String("since", "", "Only include workspaces active since given duration (e.g. 1h, 30m)")
 	statusCmd.Flags().Bool("watch", false, "Continuously watch and refresh status output")
 
@@ -189,6 +195,7 @@ func runStatusOnce(w io.Writer, cfg *utils.ZestConfig, args []string, jsonOut, v
 
 	var actives []*workspace.WspRuntime
 	var inactives []*workspace.WspConfig
+	gits := map[string]*launch.GitStatus{}
 
 	for _, name := range selected {
 		wspCfg, ok := registry.GetCfg(name)
@@ -198,6 +205,9 @@ func runStatusOnce(w io.Writer, cfg *utils.ZestConfig, args []string, jsonOut, v
 
 		if wspCfg.Status == workspace.Inactive {
 			inactives = append(inactives, wspCfg)
+			if verbose {
+				addGitStatus(gits, cfg, name, "")
+			}
 			continue
 		}
 
@@ -215,22 +225,66 @@ func runStatusOnce(w io.Writer, cfg *utils.ZestConfig, args []string, jsonOut, v
 		}
 
 		actives = append(actives, rt)
+		if verbose {
+			addGitStatus(gits, cfg, name, rt.Profile)
+		}
 	}
 
 	if jsonOut {
-		return renderJSON(w, actives, inactives, skipped)
+		return renderJSON(w, actives, inactives, skipped, gits)
+	}
+
+	if err := renderStatusTable(w, actives, inactives, skipped, verbose); err != nil {
+		return err
 	}
+	return renderGitTable(w, gits)
+}
 
-	return renderStatusTable(w, actives, inactives, skipped, verbose)
+// addGitStatus records where the git checkout of a workspace stands, if it
+// has one. Workspaces whose checkout can't be read are left out.
+func addGitStatus(gits map[string]*launch.GitStatus, cfg *utils.ZestConfig, name, profile string) {
+	plan, err := launch.NewLaunchPlan(cfg, name, profile)
+	if err != nil {
+		return
+	}
+	if status, err := plan.GitStatus(); err == nil && status != nil {
+		gits[name] = status
+	}
 }
 
-func renderJSON(w io.Writer, actives []*workspace.WspRuntime, inactives []*workspace.WspConfig, skipped []string) error {
+func renderGitTable(w io.Writer, gits map[string]*launch.GitStatus) error {
+	if len(gits) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nGIT")
+	fmt.Fprintln(tw, "NAME\tBRANCH\tAHEAD\tBEHIND\tCHANGES\tDIR")
+	for _, name := range slices.Sorted(maps.Keys(gits)) {
+		git := gits[name]
+		ahead, behind := "-", "-"
+		if git.Upstream != "" {
+			ahead, behind = strconv.Itoa(git.Ahead), strconv.Itoa(git.Behind)
+		}
+		changes := "clean"
+		if git.Dirty {
+			changes = "dirty"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", name, git.Branch, ahead, behind, changes, git.Dir)
+	}
+	return tw.Flush()
+}
+
+func renderJSON(w io.Writer, actives []*workspace.WspRuntime, inactives []*workspace.WspConfig, skipped []string, gits map[string]*launch.GitStatus) error {
 	report := StatusReport{
 		Skipped:   skipped,
 		Inactive:  inactives,
 		Active:    actives,
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
+	if len(gits) > 0 {
+		report.Git = gits
+	}
 	b, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal status report: %w", err)
@@ -259,13 +313,14 @@ func renderStatusTable(w io.Writer, actives []*workspace.WspRuntime, inactives [
 	// Active
 	if len(actives) > 0 {
 		fmt.Fprintln(tw, "\nACTIVE WORKSPACES")
-		fmt.Fprintln(tw, "NAME\tSTATUS\tSTARTED_AT\tPIDS\tPROCESSES")
+		fmt.Fprintln(tw, "NAME\tSTATUS\tSTARTED_AT\tPIDS\tPROCESSES\tPROFILE")
 		for _, wsp := range actives {
-			fmt.Fprintf(tw, "%s\tActive\t%s\t%s\t%s\n",
+			fmt.Fprintf(tw, "%s\tActive\t%s\t%s\t%s\t%s\n",
 				wsp.Name,
 				wsp.StartedAt,
 				truncate(joinInts(flatten(wsp.PIDs)), 30),
 				wrapEmptyOutput(strings.Join(wsp.Processes, ",")),
+				wrapEmptyOutput(wsp.Profile),
 			)
 		}
 		tw.Flush()
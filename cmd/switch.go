@@ -0,0 +1,252 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var ErrNoPreviousWorkspace utils.ZestErr = errors.New("no previous workspace to switch to")
+
+// closedWorkspace is what a switch needs to launch a closed workspace again.
+type closedWorkspace struct {
+	name string
+	opts LaunchOptions
+}
+
+// switchCmd represents the switch command
+func NewSwitchCmd(cfg *utils.ZestConfig) *cobra.Command {
+	var switchCmd = &cobra.Command{
+		Use:   "switch [workspace-name]",
+		Short: "Close the active workspaces and launch another one",
+		Long: `Closes every active workspace, or only those of the group given with
+--close-group, and launches the target workspace in one step.
+
+If the target fails to launch, the closed workspaces are launched again the
+way they were running. Use - as the workspace name to go back to the
+workspace that was used last, like cd -.`,
+		Example: `  zest switch personal
+  zest switch -
+  zest switch client-a --close-group clients
+  zest switch work --profile review --grace 10s`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			group, err := cmd.Flags().GetString("close-group")
+			if err != nil {
+				return err
+			}
+			profile, err := cmd.Flags().GetString("profile")
+			if err != nil {
+				return err
+			}
+			env, err := cmd.Flags().GetStringToString("env")
+			if err != nil {
+				return err
+			}
+			verbose, err := cmd.Flags().GetBool("verbose")
+			if err != nil {
+				return err
+			}
+
+			opts := LaunchOptions{Options: launch.Options{Profile: profile, Env: env}}
+			return switchWorkspace(cmd.OutOrStdout(), cfg, args[0], group, opts, verbose)
+		},
+	}
+
+	switchCmd.Flags().String("close-group", "", "Close only the active workspaces of this group")
+	switchCmd.Flags().StringP("profile", "p", "", "Profile of the workspace to launch")
+	switchCmd.Flags().StringToString("env", nil, "Set or override environment variables (e.g. --env KEY=VALUE)")
+	switchCmd.Flags().Duration("grace", 0, "Time apps get to exit before they are killed (default from close.grace_period)")
+	bindSetting(switchCmd, "grace", "close.grace_period")
+	switchCmd.Flags().BoolP("verbose", "v", false, "Show the output of every close and launch")
+	return switchCmd
+}
+
+func switchWorkspace(w io.Writer, cfg *utils.ZestConfig, target, group string, opts LaunchOptions, verbose bool) error {
+	wspReg, err := workspace.NewWspRegistry(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to load workspace registry: %w", err)
+	}
+
+	if target == "-" {
+		if target, err = previousWorkspace(wspReg); err != nil {
+			return err
+		}
+	}
+	targetCfg, ok := wspReg.GetCfg(target)
+	if !ok {
+		return fmt.Errorf("%w: '%s'", workspace.ErrWorkspaceNotExists, target)
+	}
+
+	// only the summary is shown unless asked for, hook output is in the session logs
+	out := io.Discard
+	if verbose {
+		out = w
+	}
+
+	closed, closeErrs := closeForSwitch(out, cfg, wspReg, target, group)
+	for _, err := range closeErrs {
+		fmt.Fprintf(w, "Warning: %v\n", err)
+	}
+
+	if targetCfg.Status == workspace.Active {
+		fmt.Fprintf(w, "Workspace '%s' is already active.\n", target)
+	} else if err := launchWorkspace(out, cfg, opts, target); err != nil {
+		if rbErr := rollbackLaunch(out, cfg, target); rbErr != nil {
+			fmt.Fprintf(w, "Warning: %v\n", rbErr)
+		}
+		restored, failed := relaunchAll(out, cfg, closed)
+		msg := fmt.Sprintf("failed to switch to '%s'", target)
+		if len(restored) > 0 {
+			msg += fmt.Sprintf(", restored %s", strings.Join(restored, ", "))
+		}
+		if len(failed) > 0 {
+			msg += fmt.Sprintf(", could not restore %s", strings.Join(failed, ", "))
+		}
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	names := []string{}
+	for _, c := range closed {
+		names = append(names, c.name)
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(w, "Switched to '%s'.\n", target)
+	} else {
+		fmt.Fprintf(w, "Switched to '%s' (closed %s).\n", target, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// closeForSwitch closes the active workspaces other than target, only those
+// of group if it is set. It returns how to launch each of them again.
+func closeForSwitch(w io.Writer, cfg *utils.ZestConfig, wspReg *workspace.WspRegistry, target, group string) ([]closedWorkspace, []error) {
+	closed := []closedWorkspace{}
+	errs := []error{}
+
+	names := wspReg.GetNames()
+	sort.Strings(names)
+	for _, name := range names {
+		wspCfg, ok := wspReg.GetCfg(name)
+		if !ok || name == target || wspCfg.Status != workspace.Active {
+			continue
+		}
+
+		wspRt, err := workspace.NewWspRuntime(cfg, name)
+		if err == nil {
+			err = wspRt.Load()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to load runtime for '%s': %w", name, err))
+			continue
+		}
+		if group != "" && wspRt.Group != group {
+			continue
+		}
+
+		opts := LaunchOptions{Options: wspRt.Launch}
+		if len(wspRt.Apps) == len(wspRt.PIDs) {
+			opts.Apps = append([]string{}, wspRt.Apps...)
+		}
+
+		fmt.Fprintf(w, "Closing workspace '%s'...\n", name)
+		if err := closeWorkspace(cfg, wspReg, wspCfg, w); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close workspace '%s': %w", name, err))
+			continue
+		}
+		closed = append(closed, closedWorkspace{name: name, opts: opts})
+	}
+	return closed, errs
+}
+
+// rollbackLaunch stops a target that became active although its launch
+// failed, e.g. in a post_launch hook, so it doesn't keep running next to the
+// workspaces that are launched again. Its close hooks are skipped.
+func rollbackLaunch(w io.Writer, cfg *utils.ZestConfig, target string) error {
+	wspReg, err := workspace.NewWspRegistry(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to load workspace registry: %w", err)
+	}
+	if wspCfg, ok := wspReg.GetCfg(target); !ok || wspCfg.Status != workspace.Active {
+		return nil
+	}
+
+	if err := stopSession(w, cfg, target); err != nil {
+		return err
+	}
+	wspRt, err := workspace.NewWspRuntime(cfg, target)
+	if err != nil {
+		return fmt.Errorf("failed to initialize runtime for '%s': %w", target, err)
+	}
+	if err := wspRt.Delete(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to cleanup runtime for '%s': %w", target, err)
+	}
+	return wspReg.Transact(func() error {
+		entry, ok := wspReg.GetCfg(target)
+		if !ok {
+			return workspace.ErrWorkspaceNotExists
+		}
+		entry.Status = workspace.Inactive
+		return nil
+	})
+}
+
+// relaunchAll launches the closed workspaces again after a failed switch.
+func relaunchAll(w io.Writer, cfg *utils.ZestConfig, closed []closedWorkspace) (restored, failed []string) {
+	for _, c := range closed {
+		if err := launchWorkspace(w, cfg, c.opts, c.name); err != nil {
+			failed = append(failed, c.name)
+			continue
+		}
+		restored = append(restored, c.name)
+	}
+	return restored, failed
+}
+
+// previousWorkspace returns the inactive workspace that was closed last.
+func previousWorkspace(wspReg *workspace.WspRegistry) (string, error) {
+	previous, lastUsed := "", ""
+	for _, name := range wspReg.GetNames() {
+		wspCfg, ok := wspReg.GetCfg(name)
+		if !ok || wspCfg.Status == workspace.Active || wspCfg.LastUsed == "never" {
+			continue
+		}
+		// RFC3339 timestamps sort as strings
+		if wspCfg.LastUsed > lastUsed || (wspCfg.LastUsed == lastUsed && name < previous) {
+			previous, lastUsed = name, wspCfg.LastUsed
+		}
+	}
+	if previous == "" {
+		return "", ErrNoPreviousWorkspace
+	}
+	return previous, nil
+}
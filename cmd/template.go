@@ -0,0 +1,253 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/AVAniketh0905/zest/internal/templates"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// TemplateInfo is the JSON view of a template.
+type TemplateInfo struct {
+	Name        string               `json:"name"`
+	Source      string               `json:"source"` // builtin or the path of the user template
+	Description string               `json:"description"`
+	Variables   []templates.Variable `json:"variables"`
+}
+
+// templateCmd represents the template command
+func NewTemplateCmd(cfg *utils.ZestConfig) *cobra.Command {
+	templateCmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage workspace templates",
+		Long: `Lists, shows, saves and deletes the templates used by 'zest init --template'.
+
+Built-in templates ship with zest, user templates live in ~/.zest/templates and
+take precedence over a built-in template of the same name.
+
+'zest template save' turns an existing workspace into a template. Its
+workspace_dir becomes a required variable and every absolute path below it is
+rewritten relative to that variable.`,
+		Example: `  zest template list
+  zest template show go-dev
+  zest template save api my-go --description "Go API with docs"
+  zest init billing --template my-go --set workspace_dir=~/src/billing
+  zest template delete my-go`,
+		Args: cobra.NoArgs,
+	}
+
+	templateCmd.AddCommand(newTemplateListCmd(cfg))
+	templateCmd.AddCommand(newTemplateShowCmd(cfg))
+	templateCmd.AddCommand(newTemplateSaveCmd(cfg))
+	templateCmd.AddCommand(newTemplateDeleteCmd(cfg))
+
+	return templateCmd
+}
+
+func newTemplateListCmd(cfg *utils.ZestConfig) *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List built-in and user templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOut, err := jsonOutput(cmd, cfg)
+			if err != nil {
+				return err
+			}
+
+			list, err := templates.List(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to list templates: %w", err)
+			}
+
+			if jsonOut {
+				infos := []TemplateInfo{}
+				for _, tmpl := range list {
+					infos = append(infos, templateInfo(tmpl))
+				}
+				data, err := json.MarshalIndent(infos, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "NAME\tSOURCE\tVARIABLES\tDESCRIPTION")
+			for _, tmpl := range list {
+				names := []string{}
+				for _, v := range tmpl.Variables {
+					names = append(names, v.Name)
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+					tmpl.Name, templateSource(tmpl), strings.Join(names, ", "), tmpl.Description)
+			}
+			return tw.Flush()
+		},
+	}
+
+	listCmd.Flags().Bool("json", false, "Output in JSON format")
+
+	return listCmd
+}
+
+func newTemplateShowCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show the variables and spec of a template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tmpl, err := templates.Load(cfg, args[0])
+			if err != nil {
+				return err
+			}
+
+			printTemplate(cmd.OutOrStdout(), tmpl)
+			return nil
+		},
+	}
+}
+
+func newTemplateSaveCmd(cfg *utils.ZestConfig) *cobra.Command {
+	saveCmd := &cobra.Command{
+		Use:   "save [workspace] [template-name]",
+		Short: "Save an existing workspace as a user template",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wspName, name := args[0], args[1]
+
+			description, err := cmd.Flags().GetString("description")
+			if err != nil {
+				return err
+			}
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+			if description == "" {
+				description = fmt.Sprintf("Saved from workspace '%s'", wspName)
+			}
+
+			reg, err := workspace.NewWspRegistry(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to load workspace registry: %w", err)
+			}
+			wspCfg, ok := reg.GetCfg(wspName)
+			if !ok {
+				return fmt.Errorf("%w: '%s'", workspace.ErrWorkspaceNotExists, wspName)
+			}
+
+			data, err := os.ReadFile(wspCfg.Path)
+			if err != nil {
+				return fmt.Errorf("failed to read workspace '%s': %w", wspName, err)
+			}
+
+			tmpl, err := templates.FromSpec(name, description, data)
+			if err != nil {
+				return fmt.Errorf("failed to create template from workspace '%s': %w", wspName, err)
+			}
+			if err := tmpl.Save(cfg, force); err != nil {
+				return fmt.Errorf("failed to save template '%s': %w", name, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Template '%s' saved to %s\n", name, tmpl.Path)
+			return nil
+		},
+	}
+
+	saveCmd.Flags().StringP("description", "d", "", "Description of the template")
+	saveCmd.Flags().BoolP("force", "f", false, "Overwrite an existing user template")
+
+	return saveCmd
+}
+
+func newTemplateDeleteCmd(cfg *utils.ZestConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete a user template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := templates.Delete(cfg, name); err != nil {
+				return fmt.Errorf("failed to delete template '%s': %w", name, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Template '%s' deleted.\n", name)
+			return nil
+		},
+	}
+}
+
+func templateSource(tmpl *templates.Template) string {
+	if tmpl.Builtin {
+		return "builtin"
+	}
+	return tmpl.Path
+}
+
+func templateInfo(tmpl *templates.Template) TemplateInfo {
+	vars := tmpl.Variables
+	if vars == nil {
+		vars = []templates.Variable{}
+	}
+	return TemplateInfo{
+		Name:        tmpl.Name,
+		Source:      templateSource(tmpl),
+		Description: tmpl.Description,
+		Variables:   vars,
+	}
+}
+
+func printTemplate(w io.Writer, tmpl *templates.Template) {
+	fmt.Fprintf(w, "Name: %s\n", tmpl.Name)
+	fmt.Fprintf(w, "Source: %s\n", templateSource(tmpl))
+	fmt.Fprintf(w, "Description: %s\n", tmpl.Description)
+
+	if len(tmpl.Variables) > 0 {
+		fmt.Fprintln(w, "\nVariables:")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, v := range tmpl.Variables {
+			attrs := []string{}
+			if v.Required {
+				attrs = append(attrs, "required")
+			}
+			if v.Default != "" {
+				attrs = append(attrs, "default: "+v.Default)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", v.Name, strings.Join(attrs, ", "), v.Description)
+		}
+		tw.Flush()
+	}
+
+	fmt.Fprintln(w, "\nSpec:")
+	fmt.Fprint(w, tmpl.Body())
+}
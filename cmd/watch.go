@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 AVAniketh0905
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce groups the events of a single save, editors often write a
+// file in several steps.
+const watchDebounce = 200 * time.Millisecond
+
+// watchWorkspace applies every change to the files of an active workspace
+// until ctx ends or the workspace is closed. An edit that doesn't build a
+// valid plan is reported and leaves the running apps alone.
+func watchWorkspace(ctx context.Context, w io.Writer, cfg *utils.ZestConfig, wspName string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	files, err := watchedFiles(cfg, wspName)
+	if err != nil {
+		return err
+	}
+	if err := watchDirs(watcher, files); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Watching %d file(s) of workspace '%s' for changes...\n", len(files), wspName)
+
+	// close removes the runtime file, a stat is enough to notice it
+	wspRt, err := workspace.NewWspRuntime(cfg, wspName)
+	if err != nil {
+		return fmt.Errorf("failed to initialize runtime for '%s': %w", wspName, err)
+	}
+
+	var reload <-chan time.Time
+	active := time.NewTicker(time.Second)
+	defer active.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-active.C:
+			// stop once the workspace is closed from elsewhere
+			if _, err := os.Stat(wspRt.RtFile); errors.Is(err, os.ErrNotExist) {
+				fmt.Fprintf(w, "Workspace '%s' is no longer active, stopped watching.\n", wspName)
+				return nil
+			}
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if files[filepath.Clean(event.Name)] && !event.Has(fsnotify.Chmod) {
+				reload = time.After(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(w, "Warning: file watcher: %v\n", err)
+
+		case <-reload:
+			reload = nil
+			fmt.Fprintf(w, "Workspace '%s' changed, applying...\n", wspName)
+			if err := applyWorkspace(w, cfg, wspName, false); err != nil {
+				fmt.Fprintf(w, "Error: %v\nKeeping the current state of '%s'.\n", err, wspName)
+				continue
+			}
+
+			// includes and env files may have been added or removed
+			if next, err := watchedFiles(cfg, wspName); err == nil {
+				files = next
+				if err := watchDirs(watcher, files); err != nil {
+					fmt.Fprintf(w, "Warning: %v\n", err)
+				}
+			}
+		}
+	}
+}
+
+// watchedFiles returns the files the plan of an active workspace is built
+// from, keyed by their clean path.
+func watchedFiles(cfg *utils.ZestConfig, wspName string) (map[string]bool, error) {
+	_, wspRt, err := loadWorkspaceState(cfg, wspName)
+	if err != nil {
+		return nil, err
+	}
+	if wspRt == nil {
+		return nil, fmt.Errorf("%w: '%s'", workspace.ErrWorkspaceIsInactive, wspName)
+	}
+
+	plan, err := launch.NewLaunchPlan(cfg, wspName, wspRt.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create launch plan for '%s': %w", wspName, err)
+	}
+
+	files := map[string]bool{}
+	for _, file := range plan.WatchFiles() {
+		files[filepath.Clean(file)] = true
+	}
+	return files, nil
+}
+
+// watchDirs watches the directories of files rather than the files, so
+// editors that save by replacing the file keep being noticed.
+func watchDirs(watcher *fsnotify.Watcher, files map[string]bool) error {
+	for file := range files {
+		if err := watcher.Add(filepath.Dir(file)); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", file, err)
+		}
+	}
+	return nil
+}
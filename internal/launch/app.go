@@ -0,0 +1,215 @@
+package launch
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+var (
+	ErrNoMatchingApp utils.ZestErr = errors.New("no app matches")
+	ErrUnsupported   utils.ZestErr = errors.New("app is not supported on this system")
+)
+
+// categories group app backends under the role they play in a workspace,
+// so `--only editor` works whatever editor is configured.
+var categories = map[string]string{
+	"brave":       "browser",
+	"vscode":      "editor",
+	"neovim":      "editor",
+	"idea":        "editor",
+	"goland":      "editor",
+	"pycharm":     "editor",
+	"zed":         "editor",
+	"subl":        "editor",
+	"emacsclient": "editor",
+	"powershell":  "terminal",
+	"sioyek":      "viewer",
+}
+
+// platforms lists the systems a backend runs on, backends missing from it
+// run everywhere.
+var platforms = map[string][]string{
+	"powershell": {"windows"},
+}
+
+// Supported reports whether the backend runs on this system.
+func Supported(backend string) bool {
+	goos, ok := platforms[backend]
+	return !ok || slices.Contains(goos, runtime.GOOS)
+}
+
+// AppMeta holds the fields every app entry accepts next to its own.
+type AppMeta struct {
+	ID   string   `yaml:"id" json:"id"`     // Identifies the app within the workspace, defaults to its type
+	Tags []string `yaml:"tags" json:"tags"` // Free-form labels used by --tags
+
+	Ready *ReadyCheck `yaml:"ready,omitempty" json:"ready,omitempty"` // When the app counts as started, see `zest restart --rolling`
+	Hooks Hooks       `yaml:"hooks" json:"hooks"`                     // Commands run around the launch and close of the app
+
+	kind    string // app type as written in the spec (e.g. browser)
+	backend string // app type after resolving the configured backend (e.g. brave)
+	config  []byte // fields that decide how the app is started, see `Plan.Hash`
+}
+
+func (m *AppMeta) Meta() *AppMeta  { return m }
+func (m *AppMeta) Backend() string { return m.backend }
+
+// Matches reports whether name refers to this app, by id, by the type used
+// in the spec, by backend or by category.
+func (m *AppMeta) Matches(name string) bool {
+	return name == m.ID || name == m.kind || name == m.backend || name == categories[m.backend]
+}
+
+// HasTag reports whether the app carries any of the tags.
+func (m *AppMeta) HasTag(tags []string) bool {
+	for _, tag := range tags {
+		for _, t := range m.Tags {
+			if t == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AppFilter selects a subset of the apps of a plan.
+type AppFilter struct {
+	Only []string `json:"only,omitempty"` // launch only apps matching one of these
+	Skip []string `json:"skip,omitempty"` // leave out apps matching one of these
+	Tags []string `json:"tags,omitempty"` // launch only apps carrying one of these tags
+}
+
+func (f AppFilter) IsEmpty() bool {
+	return len(f.Only) == 0 && len(f.Skip) == 0 && len(f.Tags) == 0
+}
+
+// Selects reports whether the filter keeps the app.
+func (f AppFilter) Selects(m *AppMeta) bool {
+	if len(f.Only) > 0 && !matchesAny(m, f.Only) {
+		return false
+	}
+	if matchesAny(m, f.Skip) {
+		return false
+	}
+	if len(f.Tags) > 0 && !m.HasTag(f.Tags) {
+		return false
+	}
+	return true
+}
+
+func matchesAny(m *AppMeta, names []string) bool {
+	for _, name := range names {
+		if m.Matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter drops the apps the filter doesn't select. Names passed to Only and
+// tags that match no app at all are reported as an error, they are most
+// likely typos.
+func (ls *Plan) Filter(f AppFilter) error {
+	unknown := []string{}
+	for _, name := range f.Only {
+		if !ls.hasApp(func(m *AppMeta) bool { return m.Matches(name) }) {
+			unknown = append(unknown, name)
+		}
+	}
+	for _, tag := range f.Tags {
+		if !ls.hasApp(func(m *AppMeta) bool { return m.HasTag([]string{tag}) }) {
+			unknown = append(unknown, "tag:"+tag)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w '%s' in workspace '%s'", ErrNoMatchingApp, strings.Join(unknown, "', '"), ls.Name)
+	}
+
+	apps := []AppSpec{}
+	for _, app := range ls.Apps {
+		if f.Selects(app.Meta()) {
+			apps = append(apps, app)
+		}
+	}
+	ls.Apps = apps
+	return nil
+}
+
+// Without drops the apps whose id is in ids, e.g. apps that are already running.
+func (ls *Plan) Without(ids []string) []string {
+	running := map[string]bool{}
+	for _, id := range ids {
+		running[id] = true
+	}
+
+	dropped := []string{}
+	apps := []AppSpec{}
+	for _, app := range ls.Apps {
+		if id := app.Meta().ID; running[id] {
+			dropped = append(dropped, id)
+			continue
+		}
+		apps = append(apps, app)
+	}
+	ls.Apps = apps
+	return dropped
+}
+
+// Keep drops every app whose id is not in ids.
+func (ls *Plan) Keep(ids []string) {
+	keep := map[string]bool{}
+	for _, id := range ids {
+		keep[id] = true
+	}
+
+	apps := []AppSpec{}
+	for _, app := range ls.Apps {
+		if keep[app.Meta().ID] {
+			apps = append(apps, app)
+		}
+	}
+	ls.Apps = apps
+}
+
+// CheckSupported fails when an app can't run on this system.
+func (ls *Plan) CheckSupported() error {
+	for _, app := range ls.Apps {
+		if meta := app.Meta(); !Supported(meta.backend) {
+			return fmt.Errorf("%w: '%s' uses %s, which runs on %s, not %s", ErrUnsupported,
+				meta.ID, meta.backend, strings.Join(platforms[meta.backend], ", "), runtime.GOOS)
+		}
+	}
+	return nil
+}
+
+func (ls *Plan) hasApp(fn func(*AppMeta) bool) bool {
+	for _, app := range ls.Apps {
+		if fn(app.Meta()) {
+			return true
+		}
+	}
+	return false
+}
+
+// App returns the app with the given id.
+func (ls *Plan) App(id string) (AppSpec, bool) {
+	for _, app := range ls.Apps {
+		if app.Meta().ID == id {
+			return app, true
+		}
+	}
+	return nil, false
+}
+
+func (ls *Plan) GetAppIDs() []string {
+	ids := []string{}
+	for _, app := range ls.Apps {
+		ids = append(ids, app.Meta().ID)
+	}
+	return ids
+}
@@ -10,17 +10,31 @@ import (
 )
 
 type BraveApp struct {
+	AppMeta `yaml:",inline"`
+
 	Tabs       []string `yaml:"tabs"`        // List of URLs to open
 	ProfileDir string   `yaml:"profile_dir"` // Optional --user-data-dir
 	Args       []string `yaml:"args"`        // Optional extra args
 
 	pids []int
 	env  map[string]string
+	bin  string // set by plan from the global config
 }
 
 func (b *BraveApp) GetName() string              { return "brave" }
 func (b *BraveApp) GetPIDs() []int               { return b.pids }
 func (b *BraveApp) SetEnv(env map[string]string) { b.env = env }
+func (b *BraveApp) SetBinary(path string)        { b.bin = path }
+
+func (b *BraveApp) GetBinary() string {
+	if b.bin != "" {
+		return b.bin
+	}
+	if runtime.GOOS == "windows" {
+		return "" // resolved by `start` through the app paths registry
+	}
+	return "brave"
+}
 
 func (b *BraveApp) Start() error {
 	bef, err := utils.ListPIDs(b.GetName())
@@ -36,8 +50,10 @@ func (b *BraveApp) Start() error {
 	baseArgs = append(baseArgs, b.Tabs...)
 
 	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "windows":
+	switch {
+	case b.bin != "":
+		cmd = exec.Command(b.bin, baseArgs...)
+	case runtime.GOOS == "windows":
 		args := append([]string{"/C", "start", "", "brave.exe"}, baseArgs...)
 		cmd = exec.Command("cmd", args...)
 	default:
@@ -45,11 +61,7 @@ func (b *BraveApp) Start() error {
 	}
 
 	if b.env != nil {
-		envList := []string{}
-		for k, v := range b.env {
-			envList = append(envList, k+"="+v)
-		}
-		cmd.Env = append(cmd.Env, envList...)
+		cmd.Env = utils.Environ(b.env)
 	}
 
 	if err := cmd.Start(); err != nil {
@@ -0,0 +1,165 @@
+package launch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+// CodeWorkspace is the content of a VS Code .code-workspace file.
+type CodeWorkspace struct {
+	Folders    []CodeFolder    `json:"folders"`
+	Settings   map[string]any  `json:"settings,omitempty"`
+	Extensions *CodeExtensions `json:"extensions,omitempty"`
+}
+
+// CodeExtensions lists the extensions a VS Code workspace recommends.
+type CodeExtensions struct {
+	Recommendations []string `json:"recommendations"`
+}
+
+// CodeFolder is one root of a multi-root workspace. In a spec it is written
+// as a path, or as a path with a name.
+//
+//	folders:
+//	  - ~/src/api
+//	  - path: ~/src/web
+//	    name: frontend
+type CodeFolder struct {
+	Path string `yaml:"path" json:"path,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	URI  string `yaml:"-" json:"uri,omitempty"` // remote folders of VS Code, not opened by zest
+}
+
+func (f *CodeFolder) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		f.Path = path
+		return nil
+	}
+
+	type folder CodeFolder
+	return json.Unmarshal(data, (*folder)(f))
+}
+
+// ParseCodeWorkspace reads a .code-workspace file, which VS Code allows to
+// hold comments and trailing commas.
+func ParseCodeWorkspace(data []byte) (*CodeWorkspace, error) {
+	ws := &CodeWorkspace{}
+	if err := json.Unmarshal(stripJSONC(data), ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// stripJSONC removes the comments and trailing commas of JSON with comments.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString, escaped := false, false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && (data[i] != '*' || data[i+1] != '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			// drop a comma that only has whitespace after it
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// isMultiRoot reports whether the app opens a generated .code-workspace
+// file instead of a single folder.
+func (v *VSCodeApp) isMultiRoot() bool {
+	return len(v.Folders) > 0 || len(v.Settings) > 0 || len(v.Extensions) > 0
+}
+
+// codeWorkspace returns the .code-workspace file the app opens, with path as
+// its first folder. Relative folders start at the workspace_dir.
+func (v *VSCodeApp) codeWorkspace() *CodeWorkspace {
+	folders := []CodeFolder{}
+	if v.Path != "" {
+		folders = append(folders, CodeFolder{Path: v.Path})
+	}
+	folders = append(folders, v.Folders...)
+	if len(folders) == 0 && v.workingDir != "" {
+		folders = append(folders, CodeFolder{Path: v.workingDir})
+	}
+
+	ws := &CodeWorkspace{Folders: []CodeFolder{}, Settings: v.Settings}
+	for _, folder := range folders {
+		if folder.Path == "" {
+			continue
+		}
+		folder.Path = utils.ExpandHome(folder.Path)
+		if !filepath.IsAbs(folder.Path) && v.workingDir != "" {
+			folder.Path = filepath.Join(v.workingDir, folder.Path)
+		}
+		ws.Folders = append(ws.Folders, folder)
+	}
+	if len(v.Extensions) > 0 {
+		ws.Extensions = &CodeExtensions{Recommendations: v.Extensions}
+	}
+	return ws
+}
+
+// writeCodeWorkspace writes the .code-workspace file of the app and
+// returns its path.
+func (v *VSCodeApp) writeCodeWorkspace() (string, error) {
+	if v.workspaceFile == "" {
+		return "", fmt.Errorf("no place to write the .code-workspace file of '%s'", v.ID)
+	}
+
+	data, err := json.MarshalIndent(v.codeWorkspace(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(v.workspaceFile), 0755); err != nil {
+		return "", err
+	}
+	if err := utils.WriteFileAtomic(v.workspaceFile, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s, %v", v.workspaceFile, err)
+	}
+	return v.workspaceFile, nil
+}
@@ -9,6 +9,8 @@ import (
 )
 
 type CustomApp struct {
+	AppMeta `yaml:",inline"`
+
 	Name string   `yaml:"name"`
 	Cmd  string   `yaml:"cmd"`
 	Args []string `yaml:"args"`
@@ -17,8 +19,9 @@ type CustomApp struct {
 	env  map[string]string
 }
 
-func (c *CustomApp) GetName() string { return c.Name }
-func (c *CustomApp) GetPIDs() []int  { return c.pids }
+func (c *CustomApp) GetName() string   { return c.Name }
+func (c *CustomApp) GetPIDs() []int    { return c.pids }
+func (c *CustomApp) GetBinary() string { return c.Cmd }
 func (c *CustomApp) SetEnv(env map[string]string) {
 	c.env = env
 }
@@ -31,11 +34,7 @@ func (c *CustomApp) Start() error {
 	cmd := exec.Command(c.Cmd, c.Args...)
 
 	if c.env != nil {
-		envList := []string{}
-		for k, v := range c.env {
-			envList = append(envList, k+"="+v)
-		}
-		cmd.Env = append(cmd.Env, envList...)
+		cmd.Env = utils.Environ(c.env)
 	}
 
 	if err := cmd.Start(); err != nil {
@@ -1,26 +1,49 @@
 package launch
 
 import (
+	"encoding/json"
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AVAniketh0905/zest/internal/utils"
 )
 
 type VSCodeApp struct {
+	AppMeta `yaml:",inline"`
+
 	Path string   `yaml:"path"`           // project folder to open
 	Args []string `yaml:"args,omitempty"` // additional args
 
-	pids       []int
-	env        map[string]string
-	workingDir string // set by plan
+	// With any of these a .code-workspace file is generated and opened
+	// instead, with path as its first folder.
+	Folders    []CodeFolder   `yaml:"folders,omitempty" json:"folders,omitempty"`       // folders of a multi-root workspace
+	Settings   map[string]any `yaml:"settings,omitempty" json:"settings,omitempty"`     // settings of the workspace
+	Extensions []string       `yaml:"extensions,omitempty" json:"extensions,omitempty"` // extensions it recommends
+
+	pids          []int
+	env           map[string]string
+	workingDir    string // set by plan
+	bin           string // set by plan from the global config
+	workspaceFile string // set by plan, where the .code-workspace file is written
 }
 
 func (v *VSCodeApp) GetName() string              { return "code" }
 func (v *VSCodeApp) GetPIDs() []int               { return v.pids }
 func (v *VSCodeApp) SetEnv(env map[string]string) { v.env = env }
 func (v *VSCodeApp) SetWorkingDir(dir string)     { v.workingDir = dir }
+func (v *VSCodeApp) SetBinary(path string)        { v.bin = path }
+
+func (v *VSCodeApp) GetBinary() string {
+	if v.bin != "" {
+		return v.bin
+	}
+	return "code"
+}
 
 func (v *VSCodeApp) Start() error {
 	bef, err := utils.ListPIDs(v.GetName())
@@ -29,7 +52,13 @@ func (v *VSCodeApp) Start() error {
 	}
 
 	args := []string{}
-	if v.Path != "" {
+	if v.isMultiRoot() {
+		path, err := v.writeCodeWorkspace()
+		if err != nil {
+			return err
+		}
+		args = append(args, path)
+	} else if v.Path != "" {
 		args = append(args, v.Path)
 	} else if v.Path == "" && v.workingDir != "" {
 		args = append(args, v.workingDir)
@@ -39,15 +68,11 @@ func (v *VSCodeApp) Start() error {
 		args = append(args, v.Args...)
 	}
 
-	// Assuming VSCode is in PATH
-	cmd := exec.Command("code", args...)
+	// Assuming VSCode is in PATH unless configured otherwise
+	cmd := exec.Command(v.GetBinary(), args...)
 
 	if v.env != nil {
-		envList := []string{}
-		for k, v := range v.env {
-			envList = append(envList, fmt.Sprintf("%s=%s", k, v))
-		}
-		cmd.Env = append(cmd.Env, envList...)
+		cmd.Env = utils.Environ(v.env)
 	}
 
 	if err := cmd.Start(); err != nil {
@@ -65,8 +90,374 @@ func (v *VSCodeApp) Start() error {
 
 func (v *VSCodeApp) Summary() string {
 	out := "- [vscode] Opening folder: " + v.Path + "\n"
+	if v.isMultiRoot() {
+		ws := v.codeWorkspace()
+		folders := make([]string, 0, len(ws.Folders))
+		for _, folder := range ws.Folders {
+			folders = append(folders, folder.Path)
+		}
+		out = "- [vscode] Opening workspace: " + v.workspaceFile + "\n"
+		out += "  Folders: [" + utils.JoinQuoted(folders) + "]\n"
+		if len(v.Settings) > 0 {
+			out += fmt.Sprintf("  Settings: %d\n", len(v.Settings))
+		}
+		if len(v.Extensions) > 0 {
+			out += "  Extensions: [" + utils.JoinQuoted(v.Extensions) + "]\n"
+		}
+	}
 	if len(v.Args) > 0 {
 		out += "  Args: [" + utils.JoinQuoted(v.Args) + "]\n"
 	}
 	return out
 }
+
+// EditorApp opens a project and files in one of the editor backends, the
+// app type it was written as or the configured `editor` for `editor` apps.
+// Files are written as path, path:line or path:line:column.
+//
+//	neovim:
+//	  - files: [main.go:42:7, README.md]
+//	    pane: tmux
+//	    listen: /tmp/api.sock
+//	goland:
+//	  - path: ~/src/api
+//	    binary:
+//	      darwin: /Applications/GoLand.app/Contents/MacOS/goland
+//	      default: goland
+type EditorApp struct {
+	AppMeta `yaml:",inline"`
+
+	Path   string   `yaml:"path" json:"path"`     // project folder to open, defaults to the workspace_dir
+	Files  []string `yaml:"files" json:"files"`   // files to open, relative to path
+	Args   []string `yaml:"args" json:"args"`     // additional args
+	Binary OSBinary `yaml:"binary" json:"binary"` // executable, overrides the global `binaries`
+
+	// neovim only
+	Pane     string   `yaml:"pane" json:"pane"`         // where neovim runs: terminal (default) or tmux
+	Terminal []string `yaml:"terminal" json:"terminal"` // terminal emulator neovim is appended to, e.g. [alacritty, -e]
+	Listen   string   `yaml:"listen" json:"listen"`     // server socket, see `nvim --listen`
+
+	pids       []int
+	env        map[string]string
+	workingDir string // set by plan
+	bin        string // set by plan from the global config
+	editor     string // set by plan, the backend
+}
+
+// OSBinary is the path of an executable per operating system, keyed by
+// GOOS (linux, darwin, windows) with a `default` for the others. A plain
+// string is used on every system.
+type OSBinary map[string]string
+
+func (b *OSBinary) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*b = OSBinary{"default": path}
+		return nil
+	}
+	return json.Unmarshal(data, (*map[string]string)(b))
+}
+
+// Path returns the executable for the running system, empty if none is set.
+func (b OSBinary) Path() string {
+	if path, ok := b[runtime.GOOS]; ok {
+		return path
+	}
+	return b["default"]
+}
+
+// editorBackend describes how zest runs an editor.
+type editorBackend struct {
+	bin     string                                    // executable when none is configured
+	process func() string                             // name of the processes the editor runs as
+	open    func(e *EditorApp, bin string) [][]string // command lines that open the project and files
+}
+
+func processName(name string) func() string { return func() string { return name } }
+
+// editors are the backends of EditorApp, keyed by app type.
+var editors = map[string]editorBackend{
+	"neovim":      {bin: "nvim", process: processName("nvim"), open: openNeovim},
+	"idea":        {bin: "idea", process: processName("idea"), open: openJetBrains},
+	"goland":      {bin: "goland", process: processName("goland"), open: openJetBrains},
+	"pycharm":     {bin: "pycharm", process: processName("pycharm"), open: openJetBrains},
+	"zed":         {bin: "zed", process: zedProcess, open: openAtPosition},
+	"subl":        {bin: "subl", process: sublProcess, open: openAtPosition},
+	"emacsclient": {bin: "emacsclient", process: processName("emacs"), open: openEmacs},
+}
+
+// the zed and subl commands hand over to the editor, which runs under another name
+func zedProcess() string {
+	if runtime.GOOS == "linux" {
+		return "zed-editor"
+	}
+	return "zed"
+}
+
+func sublProcess() string {
+	if runtime.GOOS == "darwin" {
+		return "sublime text"
+	}
+	return "sublime_text"
+}
+
+// IsEditor reports whether appType is one of the backends of EditorApp.
+func IsEditor(appType string) bool {
+	_, ok := editors[appType]
+	return ok
+}
+
+func (e *EditorApp) GetName() string              { return editors[e.editor].process() }
+func (e *EditorApp) GetPIDs() []int               { return e.pids }
+func (e *EditorApp) SetEnv(env map[string]string) { e.env = env }
+func (e *EditorApp) SetWorkingDir(dir string)     { e.workingDir = dir }
+func (e *EditorApp) SetBinary(path string)        { e.bin = path }
+
+func (e *EditorApp) GetBinary() string {
+	if path := e.Binary.Path(); path != "" {
+		return utils.ExpandHome(path)
+	}
+	if e.bin != "" {
+		return e.bin
+	}
+	return editors[e.editor].bin
+}
+
+func (e *EditorApp) validate() error {
+	switch e.Pane {
+	case "", "terminal", "tmux":
+	default:
+		return fmt.Errorf("pane must be terminal or tmux, got '%s'", e.Pane)
+	}
+	if e.editor != "neovim" && (e.Pane != "" || len(e.Terminal) > 0 || e.Listen != "") {
+		return fmt.Errorf("pane, terminal and listen are only supported by neovim")
+	}
+	for _, file := range e.Files {
+		if parseFilePos(file).path == "" {
+			return fmt.Errorf("invalid file '%s'", file)
+		}
+	}
+	return nil
+}
+
+// filePos is a file to open, at a line and column when they are above 0.
+type filePos struct {
+	path         string
+	line, column int
+}
+
+// parseFilePos splits path:line:column, the line and column are optional.
+func parseFilePos(s string) filePos {
+	pos := filePos{path: s}
+	nums := []int{}
+	for len(nums) < 2 {
+		i := strings.LastIndex(pos.path, ":")
+		if i < 0 {
+			break
+		}
+		n, err := strconv.Atoi(pos.path[i+1:])
+		if err != nil || n < 1 {
+			break
+		}
+		nums = append([]int{n}, nums...)
+		pos.path = pos.path[:i]
+	}
+	if len(nums) > 0 {
+		pos.line = nums[0]
+	}
+	if len(nums) > 1 {
+		pos.column = nums[1]
+	}
+	return pos
+}
+
+// resolve makes path relative to dir.
+func resolve(dir, path string) string {
+	path = utils.ExpandHome(path)
+	if !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(dir, path)
+	}
+	return path
+}
+
+// project returns the folder to open, empty when there is none.
+func (e *EditorApp) project() string {
+	if e.Path != "" {
+		return resolve(e.workingDir, e.Path)
+	}
+	return e.workingDir
+}
+
+func (e *EditorApp) files() []filePos {
+	files := make([]filePos, 0, len(e.Files))
+	for _, file := range e.Files {
+		pos := parseFilePos(file)
+		pos.path = resolve(e.project(), pos.path)
+		files = append(files, pos)
+	}
+	return files
+}
+
+// openNeovim runs neovim with every file in its argument list, moving to
+// the position of each, in a new tmux window or a terminal emulator.
+func openNeovim(e *EditorApp, bin string) [][]string {
+	args := []string{bin}
+	if e.Listen != "" {
+		args = append(args, "--listen", utils.ExpandHome(e.Listen))
+	}
+
+	files := e.files()
+	cmds := []string{}
+	for i, file := range files {
+		if file.line > 0 {
+			cmds = append(cmds, fmt.Sprintf("%dargument | call cursor(%d, %d)", i+1, file.line, max(file.column, 1)))
+		}
+	}
+	if len(cmds) > 0 {
+		// neovim takes at most 10 -c commands, so they are chained in one
+		cmds = append(cmds, "1argument")
+		args = append(args, "-c", strings.Join(cmds, " | "))
+	}
+	args = append(args, e.Args...)
+	if len(files) == 0 && e.project() != "" {
+		args = append(args, e.project())
+	}
+	for _, file := range files {
+		args = append(args, file.path)
+	}
+
+	if e.Pane == "tmux" {
+		tmux := []string{"tmux", "new-window", "-n", e.ID}
+		if project := e.project(); project != "" {
+			tmux = append(tmux, "-c", project)
+		}
+		return [][]string{append(append(tmux, "--"), args...)}
+	}
+	return [][]string{append(e.terminal(), args...)}
+}
+
+// terminal returns the terminal emulator neovim runs in.
+func (e *EditorApp) terminal() []string {
+	if len(e.Terminal) > 0 {
+		return e.Terminal
+	}
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"wt"}
+	case "darwin":
+		return nil // Terminal.app takes no command line, see Start
+	default:
+		return []string{"x-terminal-emulator", "-e"}
+	}
+}
+
+// openJetBrains opens the project and then each file, the launchers take
+// one position per invocation.
+func openJetBrains(e *EditorApp, bin string) [][]string {
+	lines := [][]string{}
+	if project := e.project(); project != "" {
+		lines = append(lines, append(append([]string{bin}, e.Args...), project))
+	} else if len(e.Files) == 0 {
+		lines = append(lines, append([]string{bin}, e.Args...))
+	}
+	for _, file := range e.files() {
+		line := append([]string{bin}, e.Args...)
+		if file.line > 0 {
+			line = append(line, "--line", strconv.Itoa(file.line))
+		}
+		if file.column > 0 {
+			line = append(line, "--column", strconv.Itoa(file.column))
+		}
+		lines = append(lines, append(line, file.path))
+	}
+	return lines
+}
+
+// openAtPosition opens the project and files with one command, for editors
+// that understand path:line:column.
+func openAtPosition(e *EditorApp, bin string) [][]string {
+	line := append([]string{bin}, e.Args...)
+	if project := e.project(); project != "" {
+		line = append(line, project)
+	}
+	for _, file := range e.files() {
+		path := file.path
+		if file.line > 0 {
+			path += ":" + strconv.Itoa(file.line)
+		}
+		if file.column > 0 {
+			path += ":" + strconv.Itoa(file.column)
+		}
+		line = append(line, path)
+	}
+	return [][]string{line}
+}
+
+// openEmacs opens the files in a new frame, or the project in dired, and
+// starts the emacs daemon if none is running.
+func openEmacs(e *EditorApp, bin string) [][]string {
+	line := append([]string{bin, "--no-wait", "--create-frame", "--alternate-editor="}, e.Args...)
+	files := e.files()
+	if len(files) == 0 && e.project() != "" {
+		line = append(line, e.project())
+	}
+	for _, file := range files {
+		if file.line > 0 {
+			pos := "+" + strconv.Itoa(file.line)
+			if file.column > 0 {
+				pos += ":" + strconv.Itoa(file.column)
+			}
+			line = append(line, pos)
+		}
+		line = append(line, file.path)
+	}
+	return [][]string{line}
+}
+
+func (e *EditorApp) commands() [][]string {
+	return editors[e.editor].open(e, e.GetBinary())
+}
+
+func (e *EditorApp) Start() error {
+	if e.editor == "neovim" && e.Pane != "tmux" && len(e.terminal()) == 0 {
+		return fmt.Errorf("neovim needs a terminal to run in on %s, e.g. terminal: [alacritty, -e]", runtime.GOOS)
+	}
+
+	bef, err := utils.ListPIDs(e.GetName())
+	if err != nil {
+		return err
+	}
+
+	for _, line := range e.commands() {
+		cmd := exec.Command(line[0], line[1:]...)
+		cmd.Dir = e.project()
+		if e.env != nil {
+			cmd.Env = utils.Environ(e.env)
+		}
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start %s: %w", e.editor, err)
+		}
+	}
+
+	// Editors that are already running take over the files and exit, zest
+	// only owns the processes the launch started.
+	newPIDs, err := utils.WaitForNewPIDs(e.GetName(), bef, 3*time.Second)
+	if err == nil {
+		e.pids = append(e.pids, newPIDs...)
+	}
+	return nil
+}
+
+func (e *EditorApp) Summary() string {
+	out := "- [" + e.editor + "] Opening folder: " + e.project() + "\n"
+	if len(e.Files) > 0 {
+		out += "  Files:\n"
+		for _, file := range e.Files {
+			out += "    - " + file + "\n"
+		}
+	}
+	for _, line := range e.commands() {
+		out += "  Command: [" + utils.JoinQuoted(line) + "]\n"
+	}
+	return out
+}
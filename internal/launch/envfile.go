@@ -0,0 +1,61 @@
+package launch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/subosito/gotenv"
+)
+
+// loadEnvFiles layers the dotenv files of the spec on top of its env, later
+// files win. Relative paths start at dir, or the directory of the workspace
+// file when it is empty.
+func (ls *Plan) loadEnvFiles(files []string, dir string) error {
+	if dir == "" {
+		dir = ls.specDir
+	}
+
+	ls.EnvFiles = []string{}
+	for _, file := range files {
+		file = utils.ExpandHome(file)
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		ls.EnvFiles = append(ls.EnvFiles, file)
+
+		f, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed to read env file, %v", err)
+		}
+		env, err := gotenv.StrictParse(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse env file %s, %v", file, err)
+		}
+		for k, v := range env {
+			ls.Env[k] = v
+		}
+	}
+	return nil
+}
+
+// WatchFiles returns the files the plan was built from: the workspace file,
+// its bases and includes, and its env files.
+func (ls *Plan) WatchFiles() []string {
+	files := []string{}
+	for _, src := range append(append([]string{}, ls.Sources...), ls.EnvFiles...) {
+		if filepath.IsAbs(src) {
+			files = append(files, src)
+		}
+	}
+	return files
+}
+
+// Environ returns the environment commands run with inside the workspace:
+// the environment of zest, then the global env, the workspace env and its
+// env files, then extra.
+func (ls *Plan) Environ(extra map[string]string) []string {
+	return utils.Environ(ls.Env, extra)
+}
@@ -0,0 +1,239 @@
+package launch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+var (
+	ErrDirtyWorktree utils.ZestErr = errors.New("git worktree has uncommitted changes")
+	ErrGitFailed     utils.ZestErr = errors.New("git failed")
+)
+
+// Git puts the repository of a workspace on a branch before its apps start.
+// The repo defaults to the workspace_dir. With worktree the branch is checked
+// out in a worktree of its own under ~/.zest/worktrees, which becomes the
+// workspace_dir, and the repository itself is left alone.
+//
+//	git:
+//	  repo: ~/src/api
+//	  branch: feature/login
+//	  fetch: true
+//	  worktree: true
+type Git struct {
+	Repo     string `yaml:"repo" json:"repo"`         // Repository to check out, defaults to the workspace_dir
+	Branch   string `yaml:"branch" json:"branch"`     // Branch to check out, created if it doesn't exist
+	Fetch    bool   `yaml:"fetch" json:"fetch"`       // Fetch from the remotes first
+	Worktree bool   `yaml:"worktree" json:"worktree"` // Check the branch out in a worktree of its own
+}
+
+// GitStatus is where a checkout stands.
+type GitStatus struct {
+	Dir      string `json:"dir"`
+	Branch   string `json:"branch"`             // Checked out branch, "(detached)" without one
+	Upstream string `json:"upstream,omitempty"` // Branch it tracks, if any
+	Ahead    int    `json:"ahead"`
+	Behind   int    `json:"behind"`
+	Dirty    bool   `json:"dirty"` // Uncommitted or untracked changes
+}
+
+// resolve makes the repo of g absolute and returns the workspace_dir of the
+// workspace, which is the worktree of the branch when g asks for one.
+func (g *Git) resolve(workingDir, worktreeDir, wspName string) (string, error) {
+	if g.Repo == "" {
+		g.Repo = workingDir
+	}
+	if g.Repo == "" {
+		return "", fmt.Errorf("git needs a repo or a workspace_dir")
+	}
+	g.Repo = filepath.Clean(utils.ExpandHome(g.Repo))
+	if !filepath.IsAbs(g.Repo) {
+		return "", fmt.Errorf("git repo must be an absolute path, got '%s'", g.Repo)
+	}
+
+	if workingDir == "" {
+		workingDir = g.Repo
+	}
+	if !g.Worktree {
+		return workingDir, nil
+	}
+	if g.Branch == "" {
+		return "", fmt.Errorf("git worktree needs a branch")
+	}
+	return filepath.Join(worktreeDir, wspName, strings.ReplaceAll(g.Branch, "/", "-")), nil
+}
+
+func (g *Git) summary() string {
+	out := "Git: " + g.Repo
+	if g.Branch != "" {
+		out += " on '" + g.Branch + "'"
+	}
+	if g.Worktree {
+		out += " in a worktree"
+	}
+	if g.Fetch {
+		out += ", fetched first"
+	}
+	return out + "\n"
+}
+
+// runGit runs git in dir and returns its trimmed output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("%w: git %s, %s", ErrGitFailed, strings.Join(args, " "), msg)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CheckoutGit fetches and checks out the branch of the `git:` block before
+// the apps start. A branch is only switched in a clean checkout unless
+// allowDirty is set, git still refuses a switch that would lose changes.
+// Progress is written to w.
+func (ls *Plan) CheckoutGit(w io.Writer, allowDirty bool) error {
+	g := ls.Git
+	if g == nil {
+		return nil
+	}
+	if !utils.BinaryExists("git") {
+		return fmt.Errorf("%w: git is not installed", ErrGitFailed)
+	}
+
+	if g.Fetch {
+		fmt.Fprintf(w, "Fetching %s...\n", g.Repo)
+		if _, err := runGit(g.Repo, "fetch", "--all", "--quiet"); err != nil {
+			return err
+		}
+	}
+	if g.Branch == "" {
+		return nil
+	}
+
+	exists, err := g.branchExists()
+	if err != nil {
+		return err
+	}
+
+	if g.Worktree {
+		if _, err := os.Stat(ls.WorkingDir); err == nil {
+			return nil
+		}
+		fmt.Fprintf(w, "Adding a worktree for '%s' at %s...\n", g.Branch, ls.WorkingDir)
+		if exists {
+			_, err = runGit(g.Repo, "worktree", "add", ls.WorkingDir, g.Branch)
+		} else {
+			_, err = runGit(g.Repo, "worktree", "add", "-b", g.Branch, ls.WorkingDir)
+		}
+		return err
+	}
+
+	current, err := runGit(g.Repo, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return err
+	}
+	if current == g.Branch {
+		return nil
+	}
+
+	if !allowDirty {
+		changes, err := runGit(g.Repo, "status", "--porcelain")
+		if err != nil {
+			return err
+		}
+		if changes != "" {
+			return fmt.Errorf("%w: %s is on '%s', commit or stash them before switching to '%s', or launch with --allow-dirty",
+				ErrDirtyWorktree, g.Repo, current, g.Branch)
+		}
+	}
+
+	fmt.Fprintf(w, "Switching %s from '%s' to '%s'...\n", g.Repo, current, g.Branch)
+	if exists {
+		_, err = runGit(g.Repo, "checkout", "--quiet", g.Branch)
+	} else {
+		_, err = runGit(g.Repo, "checkout", "--quiet", "-b", g.Branch)
+	}
+	return err
+}
+
+// branchExists reports whether the branch exists locally or on a remote,
+// where git checks it out as a tracking branch.
+func (g *Git) branchExists() (bool, error) {
+	if _, err := runGit(g.Repo, "rev-parse", "--verify", "--quiet", "refs/heads/"+g.Branch); err == nil {
+		return true, nil
+	}
+	remote, err := runGit(g.Repo, "for-each-ref", "--format=%(refname)", "refs/remotes/*/"+g.Branch)
+	if err != nil {
+		return false, err
+	}
+	return remote != "", nil
+}
+
+// GitStatus returns where the checkout of the workspace stands: the checkout
+// of its `git:` block, or its workspace_dir when that is a repository. It
+// returns nil for workspaces without one.
+func (ls *Plan) GitStatus() (*GitStatus, error) {
+	dir := ls.WorkingDir
+	if ls.Git != nil && !ls.Git.Worktree {
+		dir = ls.Git.Repo
+	}
+	if dir == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, nil
+	}
+	if _, err := runGit(dir, "rev-parse", "--git-dir"); err != nil {
+		if ls.Git != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return ReadGitStatus(dir)
+}
+
+// ReadGitStatus reads the branch, its distance to the upstream and the
+// changes of the checkout at dir.
+func ReadGitStatus(dir string) (*GitStatus, error) {
+	out, err := runGit(dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil, err
+	}
+
+	status := &GitStatus{Dir: dir}
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "# branch.head "):
+			status.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+			if len(fields) == 2 {
+				status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			status.Dirty = true
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,54 @@
+package launch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// metaKeys are app fields that don't change how an app is started, editing
+// them doesn't make `zest apply` restart the app.
+var metaKeys = map[string]bool{"id": true, "tags": true, "ready": true, "enabled": true, "hooks": true}
+
+func startConfig(appData map[string]any) ([]byte, error) {
+	config := map[string]any{}
+	for k, v := range appData {
+		if !metaKeys[k] {
+			config[k] = v
+		}
+	}
+	return json.Marshal(config)
+}
+
+// Hash identifies the configuration an app is started with: its fields, its
+// backend and binary and the working directory. Apps with the same hash are
+// started the same way. The environment is shared by every app and left
+// out, see EnvHash, so changing a variable doesn't restart them all.
+func (ls *Plan) Hash(app AppSpec) string {
+	meta := app.Meta()
+	data, _ := json.Marshal(struct {
+		Backend    string          `json:"backend"`
+		Binary     string          `json:"binary"`
+		Config     json.RawMessage `json:"config"`
+		WorkingDir string          `json:"workspace_dir"`
+	}{meta.backend, app.GetBinary(), meta.config, ls.WorkingDir})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// EnvHash identifies the environment the apps are started with.
+func (ls *Plan) EnvHash() string {
+	data, _ := json.Marshal(ls.Env)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// GetHashes returns the hash of every app, keyed by app id.
+func (ls *Plan) GetHashes() map[string]string {
+	hashes := map[string]string{}
+	for _, app := range ls.Apps {
+		hashes[app.Meta().ID] = ls.Hash(app)
+	}
+	return hashes
+}
@@ -0,0 +1,228 @@
+package launch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+var (
+	ErrHookFailed utils.ZestErr = errors.New("hook failed")
+	ErrPostLaunch utils.ZestErr = errors.New("post_launch hook failed") // the app it ran for is running and must be tracked
+)
+
+// DefaultHookTimeout is how long a hook may run when it doesn't set a timeout.
+const DefaultHookTimeout = time.Minute
+
+// Lifecycle stages hooks run at.
+const (
+	PreLaunch  = "pre_launch"
+	PostLaunch = "post_launch"
+	PreClose   = "pre_close"
+	PostClose  = "post_close"
+)
+
+// Hook is a shell command run at a stage of the workspace or app lifecycle.
+//
+//	hooks:
+//	  pre_launch:
+//	    - cmd: git fetch
+//	      timeout: 30s
+//	      continue_on_error: true
+type Hook struct {
+	Cmd             string            `yaml:"cmd" json:"cmd"`                             // Command run by the system shell
+	Cwd             string            `yaml:"cwd" json:"cwd"`                             // Directory to run in, relative to the workspace_dir
+	Env             map[string]string `yaml:"env" json:"env"`                             // Variables set on top of the workspace env
+	Timeout         string            `yaml:"timeout" json:"timeout"`                     // How long the hook may run, e.g. 30s
+	ContinueOnError bool              `yaml:"continue_on_error" json:"continue_on_error"` // Keep going when the hook fails
+}
+
+// Hooks lists the hooks of a workspace or an app by stage.
+type Hooks struct {
+	PreLaunch  []Hook `yaml:"pre_launch" json:"pre_launch"`
+	PostLaunch []Hook `yaml:"post_launch" json:"post_launch"`
+	PreClose   []Hook `yaml:"pre_close" json:"pre_close"`
+	PostClose  []Hook `yaml:"post_close" json:"post_close"`
+}
+
+// Stage returns the hooks run at stage.
+func (h Hooks) Stage(stage string) []Hook {
+	switch stage {
+	case PreLaunch:
+		return h.PreLaunch
+	case PostLaunch:
+		return h.PostLaunch
+	case PreClose:
+		return h.PreClose
+	case PostClose:
+		return h.PostClose
+	}
+	return nil
+}
+
+func (h Hooks) IsEmpty() bool {
+	return len(h.PreLaunch) == 0 && len(h.PostLaunch) == 0 && len(h.PreClose) == 0 && len(h.PostClose) == 0
+}
+
+func (h Hooks) summary(indent string) string {
+	out := ""
+	for _, stage := range []string{PreLaunch, PostLaunch, PreClose, PostClose} {
+		for _, hook := range h.Stage(stage) {
+			out += indent + stage + ": " + hook.Cmd
+			if hook.Cwd != "" {
+				out += " (in " + hook.Cwd + ")"
+			}
+			if hook.ContinueOnError {
+				out += " [continue on error]"
+			}
+			out += "\n"
+		}
+	}
+	return out
+}
+
+func (h Hook) validate() error {
+	if h.Cmd == "" {
+		return errors.New("hook without cmd")
+	}
+	if h.Timeout != "" {
+		if _, err := time.ParseDuration(h.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout for hook '%s', %v", h.Cmd, err)
+		}
+	}
+	return nil
+}
+
+func (h Hooks) validate() error {
+	for _, stage := range []string{PreLaunch, PostLaunch, PreClose, PostClose} {
+		for _, hook := range h.Stage(stage) {
+			if err := hook.validate(); err != nil {
+				return fmt.Errorf("%s: %v", stage, err)
+			}
+		}
+	}
+	return nil
+}
+
+// lockedWriter lets apps started in parallel share the session log.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// SetLog sends the output of every hook to w, e.g. the session log.
+func (ls *Plan) SetLog(w io.Writer) {
+	ls.log = &lockedWriter{w: w}
+}
+
+// RunHooks runs the workspace hooks of stage.
+func (ls *Plan) RunHooks(stage string) error {
+	return ls.runHooks(ls.Hooks.Stage(stage), stage, "")
+}
+
+// RunAppHooks runs the hooks of stage of a single app.
+func (ls *Plan) RunAppHooks(app AppSpec, stage string) error {
+	return ls.runHooks(app.Meta().Hooks.Stage(stage), stage, app.Meta().ID)
+}
+
+// runHooks runs hooks in order and stops at the first failure, unless
+// that hook may fail.
+func (ls *Plan) runHooks(hooks []Hook, stage, appID string) error {
+	log := io.Writer(io.Discard)
+	if ls.log != nil {
+		log = ls.log
+	}
+
+	name := stage
+	if appID != "" {
+		name = appID + " " + stage
+	}
+
+	for _, hook := range hooks {
+		fmt.Fprintf(log, "[%s] %s: %s\n", time.Now().Format(time.RFC3339), name, hook.Cmd)
+		err := hook.run(log, ls.workDir(hook.Cwd), ls.Env)
+		if err == nil {
+			continue
+		}
+		if hook.ContinueOnError {
+			fmt.Fprintf(log, "[%s] %s: '%s' failed, continuing: %v\n", time.Now().Format(time.RFC3339), name, hook.Cmd, err)
+			continue
+		}
+		fmt.Fprintf(log, "[%s] %s: '%s' failed: %v\n", time.Now().Format(time.RFC3339), name, hook.Cmd, err)
+		return fmt.Errorf("%w: %s '%s', %v", ErrHookFailed, name, hook.Cmd, err)
+	}
+	return nil
+}
+
+// shellCommand runs line with the system shell in dir, with the env layers
+// set on top of the environment of zest, later layers win.
+func shellCommand(ctx context.Context, line, dir string, layers ...map[string]string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", line)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", line)
+	}
+	cmd.Dir = dir
+	cmd.Env = utils.Environ(layers...)
+	return cmd
+}
+
+// shellQuote quotes arg so the system shell passes it on unchanged.
+func shellQuote(arg string) string {
+	if runtime.GOOS == "windows" {
+		if arg == "" || strings.ContainsAny(arg, " \t\"&|<>^") {
+			return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+		}
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// workDir resolves cwd of a hook or task against the workspace_dir.
+func (ls *Plan) workDir(cwd string) string {
+	cwd = utils.ExpandHome(cwd)
+	if filepath.IsAbs(cwd) {
+		return cwd
+	}
+	if ls.WorkingDir != "" {
+		return filepath.Join(ls.WorkingDir, cwd)
+	}
+	return cwd
+}
+
+func (h Hook) run(w io.Writer, dir string, env map[string]string) error {
+	timeout := DefaultHookTimeout
+	if h.Timeout != "" {
+		timeout, _ = time.ParseDuration(h.Timeout)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, h.Cmd, dir, env, h.Env)
+	killGroupOnCancel(cmd)
+	cmd.WaitDelay = time.Second // children may keep the output open after a timeout
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+	return err
+}
@@ -2,7 +2,6 @@ package launch
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"runtime"
 	"time"
@@ -11,17 +10,38 @@ import (
 )
 
 type SioyekApp struct {
+	AppMeta `yaml:",inline"`
+
 	Path  string   `yaml:"path"`  // Override default path to binary
 	Files []string `yaml:"files"` // Each file will open in a new sioyek instance
 	Args  []string `yaml:"args"`
 
 	pids []int
 	env  map[string]string
+	bin  string // set by plan from the global config
 }
 
 func (s *SioyekApp) GetName() string              { return "sioyek" }
 func (s *SioyekApp) GetPIDs() []int               { return s.pids }
 func (s *SioyekApp) SetEnv(env map[string]string) { s.env = env }
+func (s *SioyekApp) SetBinary(path string)        { s.bin = path }
+
+func (s *SioyekApp) GetBinary() string {
+	if s.Path != "" {
+		return s.Path
+	}
+	if s.bin != "" {
+		return s.bin
+	}
+	switch runtime.GOOS {
+	case "windows":
+		return `C:\Program Files\sioyek\sioyek.exe`
+	case "darwin":
+		return "/Applications/sioyek.app/Contents/MacOS/sioyek"
+	default: // linux
+		return "/usr/bin/sioyek"
+	}
+}
 
 func (s *SioyekApp) Start() error {
 	name := s.GetName()
@@ -32,28 +52,14 @@ func (s *SioyekApp) Start() error {
 	}
 
 	for _, filePath := range s.Files {
-		binaryPath := s.Path
-		if binaryPath == "" {
-			switch runtime.GOOS {
-			case "windows":
-				binaryPath = `C:\Program Files\sioyek\sioyek.exe`
-			case "darwin":
-				binaryPath = "/Applications/sioyek.app/Contents/MacOS/sioyek"
-			default: // linux
-				binaryPath = "/usr/bin/sioyek"
-			}
-		}
+		binaryPath := s.GetBinary()
 
 		args := []string{"--new-window", filePath}
 		args = append(args, s.Args...)
 		cmd := exec.Command(binaryPath, args...)
 
 		if len(s.env) > 0 {
-			envList := os.Environ()
-			for k, v := range s.env {
-				envList = append(envList, fmt.Sprintf("%s=%s", k, v))
-			}
-			cmd.Env = envList
+			cmd.Env = utils.Environ(s.env)
 		}
 
 		if err := cmd.Start(); err != nil {
@@ -1,17 +1,28 @@
 package launch
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 
 	"github.com/AVAniketh0905/zest/internal/utils"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v3"
 )
 
 type AppSpec interface {
 	GetName() string
 	GetPIDs() []int
+	GetBinary() string // executable the app runs, empty if it can't be resolved up front
+	Meta() *AppMeta    // id, tags and type shared by every app
 
 	SetEnv(map[string]string)
 
@@ -20,44 +31,222 @@ type AppSpec interface {
 }
 
 type Plan struct {
-	Name       string
-	WorkingDir string
+	Name        string
+	Profile     string // profile the apps were selected with, empty for the default
+	Group       string // group the workspace belongs to, see `zest switch --close-group`
+	WorkingDir  string
+	Env         map[string]string
+	Parallelism int   // number of apps started at the same time
+	Hooks       Hooks // commands run around the launch and close of the workspace
+	Git         *Git  // branch the repository is put on before the apps start
+
+	Tasks map[string]Task // one-off commands run with `zest run`
+
+	Sources  []string // files the spec was resolved from, base first
+	Spec     []byte   // spec after extends and include were resolved
+	EnvFiles []string // dotenv files the env was read from
+	specDir  string   // directory env files are relative to without a workspace_dir
+
+	entries map[string]int // app entries of each type before the profile dropped any
+
+	worktreeDir      string // where the worktrees of `git.worktree` are added
+	codeWorkspaceDir string // where the .code-workspace files of vscode apps are written
 
 	Apps []AppSpec
+
+	log      io.Writer // where hooks write their output, see SetLog
+	starting sync.Map  // process name -> *sync.Mutex, see StartApp
+}
+
+// Options are the choices a workspace was launched with. They are saved in
+// its runtime so `zest restart` can launch it the same way again.
+type Options struct {
+	Profile string            `json:"profile,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	Filter  AppFilter         `json:"filter"`
+	Detach  bool              `json:"detach,omitempty"`
 }
 
 type rawPlanYAML struct {
-	Name       string `yaml:"name"`
-	WorkingDir string `yaml:"workspace_dir"`
+	Version    int               `yaml:"version"`
+	Name       string            `yaml:"name"`
+	WorkingDir string            `yaml:"workspace_dir"`
+	Group      string            `yaml:"group"`
+	Env        map[string]string `yaml:"env"`
+	EnvFiles   []string          `yaml:"env_files"`
+	Hooks      Hooks             `yaml:"hooks"`
+	Git        *Git              `yaml:"git"`
+	Tasks      map[string]Task   `yaml:"tasks"`
 
 	Apps map[string][]map[string]any `yaml:"apps"` // dynamic decoding
 }
 
-func NewLaunchPlan(cfg *utils.ZestConfig, wspName string) (*Plan, error) {
+// NewLaunchPlan builds the plan of a workspace, profile selects one of the
+// `profiles:` of its spec and may be empty.
+func NewLaunchPlan(cfg *utils.ZestConfig, wspName, profile string) (*Plan, error) {
 	path := filepath.Join(cfg.WspDir(), wspName+".yaml")
 
 	plan := &Plan{}
 	plan.Name = wspName
-	if data, err := os.ReadFile(path); err == nil {
-		if err := plan.parse(data); err != nil {
-			return nil, err
+	plan.Profile = profile
+	plan.specDir = filepath.Dir(path)
+	plan.worktreeDir = cfg.WorktreeDir()
+	plan.codeWorkspaceDir = cfg.CodeWorkspaceDir()
+	plan.Parallelism = cfg.Settings.Launch.Parallelism
+	plan.Env = map[string]string{}
+	for k, v := range cfg.Settings.Env {
+		plan.Env[k] = v
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if profile != "" {
+			return nil, fmt.Errorf("%w: '%s', available: none", ErrProfileNotExists, profile)
 		}
+		return plan, nil
+	}
+
+	r := newResolver(cfg, wspName)
+	spec, err := r.resolve(path, data)
+	if err != nil {
+		return nil, err
+	}
+	plan.Sources = r.sources
+
+	plan.entries = countEntries(spec)
+	if err := applyProfile(spec, profile); err != nil {
+		return nil, err
+	}
+
+	if plan.Spec, err = yaml.Marshal(spec); err != nil {
+		return nil, err
+	}
+	if err := plan.parse(plan.Spec, cfg.Settings); err != nil {
+		return nil, err
 	}
 
 	return plan, nil
 }
 
-func (ls *Plan) parse(data []byte) error {
+// WorkspaceDir returns the directory the apps of a workspace run in, the
+// worktree when git.worktree is set, without building the rest of its plan:
+// env files are not read and apps are not parsed. It is empty when the spec
+// sets no workspace_dir.
+func WorkspaceDir(cfg *utils.ZestConfig, wspName string) (string, error) {
+	path := filepath.Join(cfg.WspDir(), wspName+".yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil
+	}
+
+	spec, err := newResolver(cfg, wspName).resolve(path, data)
+	if err != nil {
+		return "", err
+	}
+	raw := struct {
+		Name       string `yaml:"name"`
+		WorkingDir string `yaml:"workspace_dir"`
+		Git        *Git   `yaml:"git"`
+	}{}
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	if err := yaml.Unmarshal(out, &raw); err != nil {
+		return "", err
+	}
+	if raw.Git == nil {
+		return raw.WorkingDir, nil
+	}
+
+	name := wspName
+	if raw.Name != "" {
+		name = raw.Name
+	}
+	dir, err := raw.Git.resolve(raw.WorkingDir, cfg.WorktreeDir(), name)
+	if err != nil {
+		return "", fmt.Errorf("invalid git, %v", err)
+	}
+	return dir, nil
+}
+
+// Inherits reports whether the spec used extends or include.
+func (ls *Plan) Inherits() bool {
+	return len(ls.Sources) > 1
+}
+
+// resolveBackend maps the generic `browser` and `terminal` app types
+// to the backend configured in the global settings.
+func resolveBackend(appType string, settings utils.Settings) string {
+	switch appType {
+	case "browser":
+		return settings.Browser
+	case "terminal":
+		return settings.Terminal
+	case "editor":
+		return settings.Editor
+	}
+	return appType
+}
+
+func (ls *Plan) parse(data []byte, settings utils.Settings) error {
 	raw := rawPlanYAML{}
 	if err := yaml.Unmarshal(data, &raw); err != nil {
 		return err
 	}
 
-	ls.Name = raw.Name
+	if raw.Name != "" {
+		ls.Name = raw.Name
+	}
 	ls.WorkingDir = raw.WorkingDir
+	// env files live in the checkout, a worktree may not exist yet
+	envDir := raw.WorkingDir
+	if raw.Git != nil {
+		dir, err := raw.Git.resolve(raw.WorkingDir, ls.worktreeDir, ls.Name)
+		if err != nil {
+			return fmt.Errorf("invalid git, %v", err)
+		}
+		ls.Git, ls.WorkingDir = raw.Git, dir
+		if envDir == "" {
+			envDir = raw.Git.Repo
+		}
+	}
+	ls.Group = raw.Group
+	ls.Hooks = raw.Hooks
+	if err := ls.Hooks.validate(); err != nil {
+		return fmt.Errorf("invalid hooks, %v", err)
+	}
+	ls.Tasks = map[string]Task{}
+	for name, task := range raw.Tasks {
+		if err := task.validate(); err != nil {
+			return fmt.Errorf("invalid task '%s', %v", name, err)
+		}
+		ls.Tasks[name] = task
+	}
+	if ls.Env == nil {
+		ls.Env = map[string]string{}
+	}
+	for k, v := range raw.Env {
+		ls.Env[k] = v
+	}
+	if err := ls.loadEnvFiles(raw.EnvFiles, envDir); err != nil {
+		return err
+	}
 	ls.Apps = []AppSpec{}
 
-	for appType, appList := range raw.Apps {
+	// keep the launch order stable
+	appTypes := make([]string, 0, len(raw.Apps))
+	for appType := range raw.Apps {
+		appTypes = append(appTypes, appType)
+	}
+	sort.Strings(appTypes)
+
+	ids := map[string]bool{}
+	for _, kind := range appTypes {
+		appList := raw.Apps[kind]
+		appType := resolveBackend(kind, settings)
+		bin := settings.Binaries[appType]
+
 		for _, appData := range appList {
 			appBytes, err := json.Marshal(appData)
 			if err != nil {
@@ -72,6 +261,7 @@ func (ls *Plan) parse(data []byte) error {
 				if err := json.Unmarshal(appBytes, &brave); err != nil {
 					return err
 				}
+				brave.SetBinary(bin)
 				app = &brave
 			case "custom":
 				var custom CustomApp
@@ -84,43 +274,179 @@ func (ls *Plan) parse(data []byte) error {
 				if err := json.Unmarshal(appBytes, &shell); err != nil {
 					return err
 				}
-				shell.SetWorkingDir(raw.WorkingDir)
+				shell.SetWorkingDir(ls.WorkingDir)
+				shell.SetBinary(bin)
 				app = &shell
 			case "vscode":
 				var vscode VSCodeApp
 				if err := json.Unmarshal(appBytes, &vscode); err != nil {
 					return err
 				}
-				vscode.SetWorkingDir(raw.WorkingDir)
+				vscode.SetWorkingDir(ls.WorkingDir)
+				vscode.SetBinary(bin)
 				app = &vscode
 			case "sioyek":
 				var sioyek SioyekApp
 				if err := json.Unmarshal(appBytes, &sioyek); err != nil {
 					return err
 				}
+				sioyek.SetBinary(bin)
 				app = &sioyek
+			default:
+				if !IsEditor(appType) {
+					return fmt.Errorf("unknown app type '%s'", appType)
+				}
+				var editor EditorApp
+				if err := json.Unmarshal(appBytes, &editor); err != nil {
+					return err
+				}
+				editor.editor = appType
+				if err := editor.validate(); err != nil {
+					return fmt.Errorf("invalid %s app, %v", appType, err)
+				}
+				editor.SetWorkingDir(ls.WorkingDir)
+				editor.SetBinary(bin)
+				app = &editor
+			}
+
+			meta := app.Meta()
+			meta.kind, meta.backend = kind, appType
+			if meta.config, err = startConfig(appData); err != nil {
+				return err
+			}
+			if meta.ID == "" {
+				meta.ID = defaultID(kind, max(len(appList), ls.entries[kind]), meta.config, ids)
+			}
+			if err := meta.Hooks.validate(); err != nil {
+				return fmt.Errorf("invalid hooks of app '%s', %v", meta.ID, err)
+			}
+			if ids[meta.ID] {
+				return fmt.Errorf("duplicate app id '%s'", meta.ID)
+			}
+			ids[meta.ID] = true
+
+			if vscode, ok := app.(*VSCodeApp); ok && vscode.isMultiRoot() {
+				vscode.workspaceFile = filepath.Join(ls.codeWorkspaceDir, ls.Name+"-"+meta.ID+".code-workspace")
 			}
 
 			ls.Apps = append(ls.Apps, app)
 		}
 	}
 
+	if len(ls.Env) > 0 {
+		ls.ApplyEnv(nil)
+	}
+
 	return nil
 }
 
-// TODO: launches goroutines to start executing apps
+// countEntries returns the number of app entries of each type in spec.
+func countEntries(spec map[string]any) map[string]int {
+	counts := map[string]int{}
+	apps, _ := spec["apps"].(map[string]any)
+	for kind, list := range apps {
+		entries, _ := list.([]any)
+		counts[kind] = len(entries)
+	}
+	return counts
+}
+
+// defaultID names an app without an `id`. The only entry of its type is
+// named after the type, entries of a type with several are told apart by
+// the fields they are started with, so adding, removing or disabling
+// another entry doesn't rename them. Identical entries are numbered.
+func defaultID(kind string, entries int, config []byte, taken map[string]bool) string {
+	if entries <= 1 {
+		return kind
+	}
+	sum := sha256.Sum256(config)
+	id := kind + "-" + hex.EncodeToString(sum[:3])
+	for n := 2; taken[id]; n++ {
+		id = fmt.Sprintf("%s-%s-%d", kind, hex.EncodeToString(sum[:3]), n)
+	}
+	return id
+}
+
+// Start launches the apps, running up to Parallelism of them at a time.
+// No new app is started once one of them has failed, and none at all when
+// one of them can't run on this system. Failing post_launch hooks of apps
+// don't stop the launch, they are returned together as ErrPostLaunch once
+// every app is started.
 func (ls *Plan) Start() error {
+	if err := ls.CheckSupported(); err != nil {
+		return err
+	}
+
+	limit := ls.Parallelism
+	if limit < 1 {
+		limit = 1
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(limit)
+
+	var mu sync.Mutex
+	hookErrs := []error{}
 	for _, app := range ls.Apps {
-		if err := app.Start(); err != nil {
+		g.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			err := ls.StartApp(app)
+			if errors.Is(err, ErrPostLaunch) {
+				mu.Lock()
+				defer mu.Unlock()
+				hookErrs = append(hookErrs, err)
+				return nil
+			}
 			return err
-		}
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return errors.Join(hookErrs...)
+}
+
+// StartApp starts a single app between its pre_launch and post_launch hooks.
+// The app keeps running when a post_launch hook fails, the error then wraps
+// ErrPostLaunch.
+func (ls *Plan) StartApp(app AppSpec) error {
+	if err := ls.RunAppHooks(app, PreLaunch); err != nil {
+		return err
+	}
+	if err := ls.startProcess(app); err != nil {
+		return err
+	}
+	if err := ls.RunAppHooks(app, PostLaunch); err != nil {
+		return fmt.Errorf("%w, app '%s' is running: %w", ErrPostLaunch, app.Meta().ID, err)
 	}
 	return nil
 }
 
+// startProcess starts the app. Apps find their pids by the processes of
+// their name that appear while they start, so apps sharing a name start one
+// at a time or each would take the pids of the other.
+func (ls *Plan) startProcess(app AppSpec) error {
+	mu, _ := ls.starting.LoadOrStore(app.GetName(), &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+	return app.Start()
+}
+
+// ApplyEnv layers env on top of the workspace env and sets the result on every app.
 func (ls *Plan) ApplyEnv(env map[string]string) {
+	merged := map[string]string{}
+	for k, v := range ls.Env {
+		merged[k] = v
+	}
+	for k, v := range env {
+		merged[k] = v
+	}
+	ls.Env = merged
+
 	for _, app := range ls.Apps {
-		app.SetEnv(env)
+		app.SetEnv(merged)
 	}
 }
 
@@ -144,13 +470,31 @@ func (ls *Plan) Summary() string {
 	out := "Launch Plan Summary:\n"
 	out += "----------------------\n"
 	out += "Workspace: " + ls.Name + "\n"
+	if ls.Profile != "" {
+		out += "Profile: " + ls.Profile + "\n"
+	}
 	if ls.WorkingDir != "" {
 		out += "Working Dir: " + ls.WorkingDir + "\n"
 	}
+	if ls.Git != nil {
+		out += ls.Git.summary()
+	}
+	if ls.Inherits() {
+		out += "Resolved From:\n"
+		for _, source := range ls.Sources {
+			out += "  - " + source + "\n"
+		}
+	}
+	if !ls.Hooks.IsEmpty() {
+		out += "Hooks:\n" + ls.Hooks.summary("  ")
+	}
 	out += "\nApps:\n"
 
 	for _, app := range ls.Apps {
 		out += app.Summary()
+		if hooks := app.Meta().Hooks; !hooks.IsEmpty() {
+			out += "  Hooks:\n" + hooks.summary("    ")
+		}
 	}
 
 	return out
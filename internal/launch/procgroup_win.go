@@ -0,0 +1,16 @@
+//go:build windows
+
+package launch
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// killGroupOnCancel kills the process tree of cmd when its context ends, so
+// the children of a hook that timed out don't outlive it.
+func killGroupOnCancel(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return exec.Command("taskkill", "/PID", strconv.Itoa(cmd.Process.Pid), "/T", "/F").Run()
+	}
+}
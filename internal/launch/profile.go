@@ -0,0 +1,124 @@
+package launch
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+var ErrProfileNotExists utils.ZestErr = errors.New("profile does not exist")
+
+// applyProfile selects the apps of a flattened spec for the named profile.
+//
+//	profiles:
+//	  review:
+//	    enable: [docs]      # apps with `enabled: false` that this profile turns on
+//	    disable: [chat]     # apps this profile leaves out
+//	    override:           # merged over the spec like an include
+//	      env:
+//	        MODE: review
+//
+// Apps are matched by their `id` or by their type (e.g. browser). Without a
+// profile only apps with `enabled: false` are left out.
+func applyProfile(spec map[string]any, profile string) error {
+	profiles, _ := spec["profiles"].(map[string]any)
+	delete(spec, "profiles")
+
+	enable, disable := map[string]bool{}, map[string]bool{}
+	if profile != "" {
+		raw, ok := profiles[profile]
+		if !ok {
+			return fmt.Errorf("%w: '%s', available: %s", ErrProfileNotExists, profile, profileNames(profiles))
+		}
+		p, ok := raw.(map[string]any)
+		if raw != nil && !ok {
+			return fmt.Errorf("profile '%s' must be a map", profile)
+		}
+
+		var err error
+		if enable, err = stringSet(p["enable"]); err != nil {
+			return fmt.Errorf("profile '%s': enable %v", profile, err)
+		}
+		if disable, err = stringSet(p["disable"]); err != nil {
+			return fmt.Errorf("profile '%s': disable %v", profile, err)
+		}
+
+		if override, ok := p["override"].(map[string]any); ok {
+			if err := mergeSpec(spec, override); err != nil {
+				return fmt.Errorf("profile '%s': %v", profile, err)
+			}
+		} else if p["override"] != nil {
+			return fmt.Errorf("profile '%s': override must be a map", profile)
+		}
+	}
+
+	apps, _ := spec["apps"].(map[string]any)
+	for appType, list := range apps {
+		entries, ok := list.([]any)
+		if !ok {
+			continue
+		}
+
+		kept := []any{}
+		for _, entry := range entries {
+			id := appID(entry)
+			on := appEnabled(entry)
+			if enable[appType] || (id != "" && enable[id]) {
+				on = true
+			}
+			if disable[appType] || (id != "" && disable[id]) {
+				on = false
+			}
+			if on {
+				kept = append(kept, entry)
+			}
+		}
+		apps[appType] = kept
+	}
+
+	return nil
+}
+
+// appEnabled reports whether an app entry is launched by default.
+func appEnabled(entry any) bool {
+	m, ok := entry.(map[string]any)
+	if !ok {
+		return true
+	}
+	enabled, ok := m["enabled"].(bool)
+	return !ok || enabled
+}
+
+func stringSet(v any) (map[string]bool, error) {
+	set := map[string]bool{}
+	if v == nil {
+		return set, nil
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return nil, errors.New("must be a list of app ids or types")
+	}
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, errors.New("must be a list of app ids or types")
+		}
+		set[s] = true
+	}
+	return set, nil
+}
+
+func profileNames(profiles map[string]any) string {
+	if len(profiles) == 0 {
+		return "none"
+	}
+	names := []string{}
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,127 @@
+package launch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+// ProjectFile is the workspace file a repository carries for its team.
+const ProjectFile = ".zest.yaml"
+
+var ErrNotAllowed utils.ZestErr = errors.New("project file is not allowed")
+
+// allowList records the project files the user trusts. It is stored in
+// ~/.zest/state/allowed.json, keyed by the absolute path of the file, with
+// the hash of the content that was allowed, see resolver.hashSources. The
+// hash covers the files the project pulls in with extends and include too.
+type allowList map[string]string
+
+func allowPath(cfg *utils.ZestConfig) string {
+	return filepath.Join(cfg.StateDir(), "allowed.json")
+}
+
+// hashProject resolves the project file at path and returns the hash it
+// is allowed by.
+func hashProject(cfg *utils.ZestConfig, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read project file, %v", err)
+	}
+
+	r := newResolver(cfg, "")
+	r.files[path] = data
+	if _, err := r.resolve(path, data); err != nil {
+		return "", err
+	}
+	return r.hashSources(0), nil
+}
+
+func loadAllowList(cfg *utils.ZestConfig) (allowList, error) {
+	list := allowList{}
+	data, err := os.ReadFile(allowPath(cfg))
+	if errors.Is(err, os.ErrNotExist) {
+		return list, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse %s, %v", allowPath(cfg), err)
+	}
+	return list, nil
+}
+
+// updateAllowList applies fn to the allow list under its lock.
+func updateAllowList(cfg *utils.ZestConfig, fn func(allowList)) error {
+	lock, err := utils.LockFile(allowPath(cfg) + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock allow list, %v", err)
+	}
+	defer lock.Unlock()
+
+	list, err := loadAllowList(cfg)
+	if err != nil {
+		return err
+	}
+	fn(list)
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(allowPath(cfg), data, 0644)
+}
+
+// Allow trusts the current content of the project file at path and of the
+// files it extends and includes. Any later change to them has to be allowed
+// again.
+func Allow(cfg *utils.ZestConfig, path string) error {
+	hash, err := hashProject(cfg, path)
+	if err != nil {
+		return err
+	}
+	return updateAllowList(cfg, func(list allowList) {
+		list[path] = hash
+	})
+}
+
+// Revoke stops trusting the project file at path.
+func Revoke(cfg *utils.ZestConfig, path string) error {
+	return updateAllowList(cfg, func(list allowList) {
+		delete(list, path)
+	})
+}
+
+// checkAllowed fails unless hash is the one path was allowed with.
+func checkAllowed(cfg *utils.ZestConfig, path, hash string) error {
+	list, err := loadAllowList(cfg)
+	if err != nil {
+		return err
+	}
+
+	allowed, ok := list[path]
+	if !ok {
+		return fmt.Errorf("%w: %s, review it and run 'zest allow %s'", ErrNotAllowed, path, filepath.Dir(path))
+	}
+	if allowed != hash {
+		return fmt.Errorf("%w: %s or a file it pulls in changed since it was allowed, review them and run 'zest allow %s'", ErrNotAllowed, path, filepath.Dir(path))
+	}
+	return nil
+}
+
+// anchorProject makes the workspace_dir of a project file relative to the
+// directory holding it, and the directory itself when it has none, so the
+// file works wherever the repository is cloned.
+func anchorProject(spec map[string]any, path string) {
+	dir := filepath.Dir(path)
+	wd, _ := spec["workspace_dir"].(string)
+	wd = utils.ExpandHome(wd)
+	if !filepath.IsAbs(wd) {
+		wd = filepath.Join(dir, wd)
+	}
+	spec["workspace_dir"] = wd
+}
@@ -0,0 +1,89 @@
+package launch
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+var ErrNotReady utils.ZestErr = errors.New("app did not become ready")
+
+// DefaultReadyTimeout is how long an app gets to become ready when its
+// check doesn't say otherwise.
+const DefaultReadyTimeout = 30 * time.Second
+
+// ReadyCheck tells when a started app is ready to serve, used by rolling
+// restarts. Without a port or url an app is ready once its processes run.
+//
+//	ready:
+//	  port: 3000
+//	  timeout: 1m
+type ReadyCheck struct {
+	Port    int    `yaml:"port" json:"port"`       // TCP port on localhost that accepts connections
+	URL     string `yaml:"url" json:"url"`         // URL that answers with a status below 500
+	Timeout string `yaml:"timeout" json:"timeout"` // How long to wait, e.g. 30s
+}
+
+// WaitReady blocks until the app passes its ready check or the timeout ends.
+func WaitReady(app AppSpec) error {
+	check := app.Meta().Ready
+	if check == nil {
+		check = &ReadyCheck{}
+	}
+
+	timeout := DefaultReadyTimeout
+	if check.Timeout != "" {
+		d, err := time.ParseDuration(check.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid ready timeout for app '%s', %v", app.Meta().ID, err)
+		}
+		timeout = d
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := check.probe(app.GetPIDs())
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: '%s' after %v, %v", ErrNotReady, app.Meta().ID, timeout, err)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+func (r *ReadyCheck) probe(pids []int) error {
+	for _, pid := range pids {
+		if !utils.IsRunning(pid) {
+			return fmt.Errorf("process %d exited", pid)
+		}
+	}
+
+	if r.Port > 0 {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort("localhost", strconv.Itoa(r.Port)), time.Second)
+		if err != nil {
+			return err
+		}
+		conn.Close()
+	}
+
+	if r.URL != "" {
+		client := http.Client{Timeout: 2 * time.Second}
+		resp, err := client.Get(r.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("%s answered %s", r.URL, resp.Status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package launch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/templates"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInheritanceCycle utils.ZestErr = errors.New("workspace inheritance cycle")
+
+// templatePrefix makes `extends:` pick a template even when a workspace of
+// the same name exists.
+const templatePrefix = "template:"
+
+// resolver flattens a workspace spec with its `extends:` base and `include:`
+// fragments into a single spec.
+//
+// The base is applied first, then every include in order, then the file
+// itself. Maps are deep-merged with the later value winning. App lists of
+// the same type are appended, except for entries with an `id` that matches
+// an earlier entry, which replace it in place.
+type resolver struct {
+	cfg     *utils.ZestConfig
+	wspName string
+	vars    map[string]string // variables for templates named in extends
+
+	chain   []string          // sources being resolved, used to detect cycles
+	sources []string          // every source that contributed to the result, base first
+	files   map[string][]byte // content of the sources read from disk, by path
+}
+
+func newResolver(cfg *utils.ZestConfig, wspName string) *resolver {
+	return &resolver{cfg: cfg, wspName: wspName, vars: map[string]string{}, files: map[string][]byte{}}
+}
+
+// resolve returns the flattened spec of the file at path holding data.
+func (r *resolver) resolve(path string, data []byte) (map[string]any, error) {
+	spec := map[string]any{}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s, %v", path, err)
+	}
+	if spec == nil {
+		spec = map[string]any{}
+	}
+
+	for _, source := range r.chain {
+		if source == path {
+			return nil, fmt.Errorf("%w: %s", ErrInheritanceCycle, strings.Join(append(r.chain, path), " -> "))
+		}
+	}
+	r.chain = append(r.chain, path)
+	defer func() { r.chain = r.chain[:len(r.chain)-1] }()
+
+	// variables are read before the base, a template base needs them
+	if vars, ok := spec["vars"].(map[string]any); ok {
+		for k, v := range vars {
+			if _, ok := r.vars[k]; !ok {
+				r.vars[k] = fmt.Sprint(v)
+			}
+		}
+	}
+
+	out := map[string]any{}
+
+	if base, ok := spec["extends"]; ok {
+		name, ok := base.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("extends in %s must be the name of a workspace or template", path)
+		}
+		parent, err := r.resolveBase(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := mergeSpec(out, parent); err != nil {
+			return nil, fmt.Errorf("failed to merge '%s' into %s, %v", name, path, err)
+		}
+	}
+
+	if includes, ok := spec["include"]; ok {
+		list, ok := includes.([]any)
+		if !ok {
+			return nil, fmt.Errorf("include in %s must be a list of files", path)
+		}
+		for _, item := range list {
+			file, ok := item.(string)
+			if !ok || file == "" {
+				return nil, fmt.Errorf("include in %s must be a list of files", path)
+			}
+			fragment, err := r.resolveFile(filepath.Dir(path), file)
+			if err != nil {
+				return nil, err
+			}
+			if err := mergeSpec(out, fragment); err != nil {
+				return nil, fmt.Errorf("failed to merge '%s' into %s, %v", file, path, err)
+			}
+		}
+	}
+
+	if err := mergeSpec(out, spec); err != nil {
+		return nil, fmt.Errorf("failed to merge %s, %v", path, err)
+	}
+	r.sources = append(r.sources, path)
+
+	return out, nil
+}
+
+// resolveBase resolves the workspace or template named by `extends:`.
+func (r *resolver) resolveBase(name string) (map[string]any, error) {
+	if !strings.HasPrefix(name, templatePrefix) {
+		path := filepath.Join(r.cfg.WspDir(), name+".yaml")
+		if data, err := os.ReadFile(path); err == nil {
+			r.files[path] = data
+			return r.inherit(path, data)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	tmplName := strings.TrimPrefix(name, templatePrefix)
+	tmpl, err := templates.Load(r.cfg, tmplName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend '%s', no workspace or template of that name: %w", name, err)
+	}
+
+	vars := map[string]string{}
+	for k, v := range r.vars {
+		if _, ok := tmpl.Variable(k); ok {
+			vars[k] = v
+		}
+	}
+	vars["name"] = r.wspName
+
+	data, err := tmpl.Render(vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend '%s', %w", name, err)
+	}
+
+	path := templatePrefix + tmplName
+	if tmpl.Path != "" {
+		path = tmpl.Path
+		// the file is hashed as written, not as rendered
+		if raw, err := os.ReadFile(path); err == nil {
+			r.files[path] = raw
+		}
+	}
+	return r.inherit(path, data)
+}
+
+// resolveFile resolves a fragment named by `include:`, relative paths are
+// taken from the directory of the including file.
+func (r *resolver) resolveFile(dir, file string) (map[string]any, error) {
+	path := utils.ExpandHome(file)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read include '%s', %v", file, err)
+	}
+	r.files[path] = data
+
+	// project files come with repositories and run commands, see `zest allow`
+	if filepath.Base(path) != ProjectFile {
+		return r.inherit(path, data)
+	}
+	start := len(r.sources)
+	spec, err := r.inherit(path, data)
+	if err != nil {
+		return nil, err
+	}
+	// the files it pulls in are part of what was allowed
+	if err := checkAllowed(r.cfg, path, r.hashSources(start)); err != nil {
+		return nil, err
+	}
+	anchorProject(spec, path)
+	return spec, nil
+}
+
+// hashSources hashes the sources resolved from index start on, with the
+// content they were read with. Built-in templates are hashed by name.
+func (r *resolver) hashSources(start int) string {
+	h := sha256.New()
+	for _, source := range r.sources[start:] {
+		fmt.Fprintf(h, "%s\x00", source)
+		if data, ok := r.files[source]; ok {
+			fmt.Fprintf(h, "%d\x00", len(data))
+			h.Write(data)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// inherit resolves a base or fragment, dropping the keys that only belong
+// to the workspace that is being launched.
+func (r *resolver) inherit(path string, data []byte) (map[string]any, error) {
+	spec, err := r.resolve(path, data)
+	if err != nil {
+		return nil, err
+	}
+	delete(spec, "name")
+	delete(spec, "version")
+	return spec, nil
+}
+
+// mergeSpec merges src into dst, see resolver for the rules.
+func mergeSpec(dst, src map[string]any) error {
+	for k, v := range src {
+		switch k {
+		case "extends", "include", "vars":
+			continue
+		case "apps":
+			if err := mergeApps(dst, v); err != nil {
+				return err
+			}
+		default:
+			dst[k] = mergeValue(dst[k], v)
+		}
+	}
+	return nil
+}
+
+// mergeValue deep-merges maps, any other value replaces the old one.
+func mergeValue(dst, src any) any {
+	srcMap, ok := src.(map[string]any)
+	if !ok {
+		return src
+	}
+	dstMap, ok := dst.(map[string]any)
+	if !ok {
+		dstMap = map[string]any{}
+	}
+
+	out := map[string]any{}
+	for k, v := range dstMap {
+		out[k] = v
+	}
+	for k, v := range srcMap {
+		out[k] = mergeValue(out[k], v)
+	}
+	return out
+}
+
+// mergeApps appends the app lists of src to dst, replacing entries by id.
+func mergeApps(dst map[string]any, src any) error {
+	if src == nil {
+		return nil
+	}
+	srcApps, ok := src.(map[string]any)
+	if !ok {
+		return fmt.Errorf("apps must be a map of app types")
+	}
+	dstApps, ok := dst["apps"].(map[string]any)
+	if !ok {
+		dstApps = map[string]any{}
+	}
+
+	out := map[string]any{}
+	for appType, list := range dstApps {
+		out[appType] = list
+	}
+
+	for appType, list := range srcApps {
+		if list == nil {
+			continue
+		}
+		entries, ok := list.([]any)
+		if !ok {
+			return fmt.Errorf("apps.%s must be a list", appType)
+		}
+
+		merged, _ := out[appType].([]any)
+		merged = append([]any{}, merged...)
+		for _, entry := range entries {
+			if i := indexOfApp(merged, appID(entry)); i >= 0 {
+				merged[i] = entry
+			} else {
+				merged = append(merged, entry)
+			}
+		}
+		out[appType] = merged
+	}
+
+	dst["apps"] = out
+	return nil
+}
+
+// appID returns the `id` of an app entry, empty if it has none.
+func appID(entry any) string {
+	m, ok := entry.(map[string]any)
+	if !ok {
+		return ""
+	}
+	id, _ := m["id"].(string)
+	return id
+}
+
+func indexOfApp(list []any, id string) int {
+	if id == "" {
+		return -1
+	}
+	for i, entry := range list {
+		if appID(entry) == id {
+			return i
+		}
+	}
+	return -1
+}
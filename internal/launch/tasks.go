@@ -0,0 +1,129 @@
+package launch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+var (
+	ErrTaskNotExists utils.ZestErr = errors.New("task does not exist")
+	ErrTaskCycle     utils.ZestErr = errors.New("task dependency cycle")
+)
+
+// Task is a one-off command run in the environment of the workspace with
+// `zest run`, e.g. migrate, seed, test or lint.
+//
+//	tasks:
+//	  test:
+//	    cmd: go test ./...
+//	    deps: [generate]
+type Task struct {
+	Cmd         string            `yaml:"cmd" json:"cmd"`                 // Command run by the system shell
+	Description string            `yaml:"description" json:"description"` // Shown by `zest run --list`
+	Cwd         string            `yaml:"cwd" json:"cwd"`                 // Directory to run in, relative to the workspace_dir
+	Env         map[string]string `yaml:"env" json:"env"`                 // Variables set on top of the workspace env
+	Deps        []string          `yaml:"deps" json:"deps"`               // Tasks run before this one
+}
+
+// TaskIO connects a task to the terminal.
+type TaskIO struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// TaskNames returns the names of the tasks in order.
+func (ls *Plan) TaskNames() []string {
+	names := make([]string, 0, len(ls.Tasks))
+	for name := range ls.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TaskOrder returns the tasks to run for name, its dependencies first and
+// every task once.
+func (ls *Plan) TaskOrder(name string) ([]string, error) {
+	order := []string{}
+	done := map[string]bool{}
+
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		for i, prev := range chain {
+			if prev == name {
+				return fmt.Errorf("%w: %s", ErrTaskCycle, strings.Join(append(chain[i:], name), " -> "))
+			}
+		}
+		if done[name] {
+			return nil
+		}
+
+		task, ok := ls.Tasks[name]
+		if !ok {
+			available := "none"
+			if len(ls.Tasks) > 0 {
+				available = strings.Join(ls.TaskNames(), ", ")
+			}
+			if len(chain) > 0 {
+				return fmt.Errorf("%w: '%s', needed by '%s'", ErrTaskNotExists, name, chain[len(chain)-1])
+			}
+			return fmt.Errorf("%w: '%s', available: %s", ErrTaskNotExists, name, available)
+		}
+
+		for _, dep := range task.Deps {
+			if err := visit(dep, append(chain, name)); err != nil {
+				return err
+			}
+		}
+		done[name] = true
+		order = append(order, name)
+		return nil
+	}
+
+	if err := visit(name, nil); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// RunTask runs name after its dependencies, args are passed to name only.
+// It stops at the first task that fails, an *exec.ExitError tells its exit
+// code.
+func (ls *Plan) RunTask(name string, args []string, tio TaskIO) error {
+	order, err := ls.TaskOrder(name)
+	if err != nil {
+		return err
+	}
+
+	for _, taskName := range order {
+		task := ls.Tasks[taskName]
+
+		line := task.Cmd
+		if taskName == name {
+			for _, arg := range args {
+				line += " " + shellQuote(arg)
+			}
+		}
+
+		cmd := shellCommand(context.Background(), line, ls.workDir(task.Cwd), ls.Env, task.Env)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = tio.Stdin, tio.Stdout, tio.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("task '%s' failed: %w", taskName, err)
+		}
+	}
+	return nil
+}
+
+func (t Task) validate() error {
+	if t.Cmd == "" {
+		return errors.New("task without cmd")
+	}
+	return nil
+}
@@ -3,7 +3,6 @@ package launch
 import (
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 	"runtime"
 	"time"
@@ -12,6 +11,8 @@ import (
 )
 
 type PowerShellApp struct {
+	AppMeta `yaml:",inline"`
+
 	Tabs []string `yaml:"tabs"` // custom per-tab commands
 	Args []string `yaml:"args"` // e.g., -NoExit
 
@@ -19,12 +20,21 @@ type PowerShellApp struct {
 	env  map[string]string
 
 	workingDir string // injected from Plan
+	bin        string // injected from Plan, global config
 }
 
 func (p *PowerShellApp) GetName() string              { return "powershell" }
 func (p *PowerShellApp) GetPIDs() []int               { return p.pids }
 func (p *PowerShellApp) SetEnv(env map[string]string) { p.env = env }
 func (p *PowerShellApp) SetWorkingDir(dir string)     { p.workingDir = dir }
+func (p *PowerShellApp) SetBinary(path string)        { p.bin = path }
+
+func (p *PowerShellApp) GetBinary() string {
+	if p.bin != "" {
+		return p.bin
+	}
+	return "wt"
+}
 
 func (p *PowerShellApp) Start() error {
 	if runtime.GOOS != "windows" {
@@ -56,14 +66,10 @@ func (p *PowerShellApp) Start() error {
 			args = append(args, "-Command", tabCmd)
 		}
 
-		cmd := exec.Command("wt", args...)
+		cmd := exec.Command(p.GetBinary(), args...)
 
 		if p.env != nil {
-			envList := []string{}
-			for k, v := range p.env {
-				envList = append(envList, fmt.Sprintf("%s=%s", k, v))
-			}
-			cmd.Env = append(os.Environ(), envList...)
+			cmd.Env = utils.Environ(p.env)
 		}
 
 		if err := cmd.Start(); err != nil {
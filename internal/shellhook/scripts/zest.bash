@@ -0,0 +1,50 @@
+# zest shell integration for bash, load it from ~/.bashrc with
+#   eval "$(zest hook bash)"
+
+_zest_prompt() {
+  local wsp="${ZEST_WORKSPACE:-${_ZEST_DIR_WORKSPACE-}}"
+  [ -n "$wsp" ] && printf '(%s) ' "$wsp"
+  return 0
+}
+
+_zest_enter() {
+  local wsp state
+  read -r wsp state <<EOF
+$(command zest env --which 2>/dev/null)
+EOF
+  [ "$wsp" = "${_ZEST_DIR_WORKSPACE-}" ] && return 0
+  _ZEST_DIR_WORKSPACE="$wsp"
+  [ -n "$wsp" ] || return 0
+{{- if eq .OnEnter "ask"}}
+  if [ "$state" != active ]; then
+    printf "zest: this is workspace '%s', run 'zest launch %s' to start it.\n" "$wsp" "$wsp" >&2
+  fi
+{{- else if eq .OnEnter "env"}}
+  eval "$(command zest env "$wsp" 2>/dev/null)"
+{{- else if eq .OnEnter "launch"}}
+  [ "$state" = active ] || command zest launch "$wsp" 2>/dev/null ||
+    printf "zest: failed to launch '%s', run 'zest launch %s' to see why.\n" "$wsp" "$wsp" >&2
+{{- end}}
+  return 0
+}
+
+_zest_hook() {
+  local ret=$?
+  if [ "$PWD" != "${_ZEST_LAST_PWD-}" ]; then
+    _ZEST_LAST_PWD="$PWD"
+    _zest_enter
+  fi
+  return $ret
+}
+
+case ";${PROMPT_COMMAND-};" in
+  *";_zest_hook;"*) ;;
+  *) PROMPT_COMMAND="_zest_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+{{- if .Prompt}}
+
+case "$PS1" in
+  *_zest_prompt*) ;;
+  *) PS1='$(_zest_prompt)'"$PS1" ;;
+esac
+{{- end}}
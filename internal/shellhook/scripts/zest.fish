@@ -0,0 +1,42 @@
+# zest shell integration for fish, load it from ~/.config/fish/config.fish with
+#   zest hook fish | source
+
+function _zest_prompt
+    set -l wsp $ZEST_WORKSPACE
+    test -n "$wsp"; or set wsp $_zest_dir_workspace
+    test -n "$wsp"; and printf '(%s) ' $wsp
+    return 0
+end
+
+function _zest_enter --on-variable PWD
+    set -l match (command zest env --which 2>/dev/null | string split ' ')
+    set -l wsp $match[1]
+    set -l state $match[2]
+    test "$wsp" = "$_zest_dir_workspace"; and return 0
+    set -g _zest_dir_workspace $wsp
+    test -n "$wsp"; or return 0
+{{- if eq .OnEnter "ask"}}
+    if test "$state" != active
+        printf "zest: this is workspace '%s', run 'zest launch %s' to start it.\n" $wsp $wsp >&2
+    end
+{{- else if eq .OnEnter "env"}}
+    command zest env --shell fish $wsp 2>/dev/null | source
+{{- else if eq .OnEnter "launch"}}
+    if test "$state" != active; and not command zest launch $wsp 2>/dev/null
+        printf "zest: failed to launch '%s', run 'zest launch %s' to see why.\n" $wsp $wsp >&2
+    end
+{{- end}}
+    return 0
+end
+
+_zest_enter
+{{- if .Prompt}}
+
+if not functions -q _zest_original_prompt
+    functions -c fish_prompt _zest_original_prompt
+    function fish_prompt
+        _zest_prompt
+        _zest_original_prompt
+    end
+end
+{{- end}}
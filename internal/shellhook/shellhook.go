@@ -0,0 +1,105 @@
+package shellhook
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+//go:embed scripts/*
+var scriptFS embed.FS
+
+var (
+	ErrUnsupportedShell utils.ZestErr = errors.New("unsupported shell")
+	ErrInvalidOnEnter   utils.ZestErr = errors.New("invalid on-enter action")
+)
+
+// Shells are the shells `zest hook` and `zest env` write code for.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// OnEnterActions are what the hook does when the shell enters the
+// workspace_dir of a workspace.
+var OnEnterActions = []string{"off", "ask", "env", "launch"}
+
+// Options shape the hook script.
+type Options struct {
+	Prompt  bool   // prefix the prompt with the workspace of the directory
+	OnEnter string // one of `OnEnterActions`
+}
+
+// Script returns the hook script for shell, meant to be loaded with eval
+// from the shell's rc file.
+func Script(shell string, opts Options) (string, error) {
+	if err := checkShell(shell); err != nil {
+		return "", err
+	}
+	if !slices.Contains(OnEnterActions, opts.OnEnter) {
+		return "", fmt.Errorf("%w: '%s', expected one of %s", ErrInvalidOnEnter, opts.OnEnter, strings.Join(OnEnterActions, ", "))
+	}
+
+	tmpl, err := template.ParseFS(scriptFS, "scripts/zest."+shell)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, opts); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// validKey matches the variable names every shell can export.
+var validKey = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Exports returns the code that exports env into the running shell, sorted
+// by name. Names no shell can hold are skipped.
+func Exports(shell string, env map[string]string) (string, error) {
+	if err := checkShell(shell); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		if validKey.MatchString(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var out strings.Builder
+	for _, k := range keys {
+		if shell == "fish" {
+			fmt.Fprintf(&out, "set -gx %s %s;\n", k, fishQuote(env[k]))
+		} else {
+			fmt.Fprintf(&out, "export %s=%s;\n", k, posixQuote(env[k]))
+		}
+	}
+	return out.String(), nil
+}
+
+func checkShell(shell string) error {
+	if !slices.Contains(Shells, shell) {
+		return fmt.Errorf("%w: '%s', expected one of %s", ErrUnsupportedShell, shell, strings.Join(Shells, ", "))
+	}
+	return nil
+}
+
+// posixQuote wraps s in single quotes for bash and zsh.
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote wraps s in single quotes for fish, where backslashes escape too.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
@@ -0,0 +1,152 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// dirVar is the variable that replaces the workspace_dir of a saved workspace.
+const dirVar = "workspace_dir"
+
+// FromSpec turns a workspace spec into a template. The workspace name and
+// workspace_dir become variables, and every absolute path below
+// workspace_dir is rewritten relative to it, so the template can be
+// rendered for another project.
+func FromSpec(name, description string, data []byte) (*Template, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace spec, %v", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse workspace spec, expected a mapping")
+	}
+	root := doc.Content[0]
+
+	tmpl := &Template{Name: name, Description: description}
+	p := &parameterizer{exprs: map[string]string{}}
+
+	if node := utils.MappingValue(root, "name"); node != nil && node.Kind == yaml.ScalarNode {
+		p.replace(node, "{{ .name }}")
+	}
+
+	// escape anything that already looks like a template action
+	walkScalars(root, func(node *yaml.Node) {
+		if strings.Contains(node.Value, "{{") || strings.Contains(node.Value, "}}") {
+			p.replace(node, "{{ "+strconv.Quote(node.Value)+" }}")
+		}
+	})
+
+	if node := utils.MappingValue(root, "workspace_dir"); node != nil && node.Kind == yaml.ScalarNode {
+		if dir := utils.ExpandHome(node.Value); filepath.IsAbs(dir) {
+			dir = filepath.Clean(dir)
+			tmpl.Variables = append(tmpl.Variables, Variable{
+				Name:        dirVar,
+				Description: "Root directory of the workspace",
+				Required:    true,
+			})
+			p.replace(node, "{{ quote ."+dirVar+" }}")
+
+			// env, env_files, hook and task cwds, git.repo, include, profiles...
+			for i := 0; i+1 < len(root.Content); i += 2 {
+				switch root.Content[i].Value {
+				case "name", "version", dirVar:
+					continue
+				}
+				walkScalars(root.Content[i+1], func(node *yaml.Node) {
+					if rel, ok := below(dir, node.Value); ok {
+						p.replace(node, pathExpr(rel))
+					}
+				})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal template body, %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	tmpl.body = p.expand(buf.String())
+	if err := tmpl.Validate(); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// parameterizer swaps YAML scalars for placeholders while the document is
+// encoded and then for the template actions they stand for.
+type parameterizer struct {
+	exprs map[string]string
+}
+
+func (p *parameterizer) replace(node *yaml.Node, expr string) {
+	placeholder := fmt.Sprintf("zest_placeholder_%d", len(p.exprs))
+	p.exprs[placeholder] = expr
+
+	*node = yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Tag:         "!!str",
+		Value:       placeholder,
+		HeadComment: node.HeadComment,
+		LineComment: node.LineComment,
+		FootComment: node.FootComment,
+	}
+}
+
+func (p *parameterizer) expand(body string) string {
+	// replace the highest numbers first, placeholder_1 is a prefix of placeholder_10
+	for i := len(p.exprs) - 1; i >= 0; i-- {
+		placeholder := fmt.Sprintf("zest_placeholder_%d", i)
+		body = strings.ReplaceAll(body, placeholder, p.exprs[placeholder])
+	}
+	return body
+}
+
+// walkScalars calls fn for every scalar value below node, mapping keys are skipped.
+func walkScalars(node *yaml.Node, fn func(*yaml.Node)) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		fn(node)
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			walkScalars(node.Content[i], fn)
+		}
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkScalars(child, fn)
+		}
+	}
+}
+
+// below reports whether value is an absolute path inside dir and returns it
+// relative to dir.
+func below(dir, value string) (string, bool) {
+	path := utils.ExpandHome(value)
+	if !filepath.IsAbs(path) {
+		return "", false
+	}
+	rel, err := filepath.Rel(dir, filepath.Clean(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// pathExpr returns the template action rendering rel below the workspace_dir variable.
+func pathExpr(rel string) string {
+	if rel == "." {
+		return "{{ quote ." + dirVar + " }}"
+	}
+	return fmt.Sprintf("{{ quote (join .%s %s) }}", dirVar, strconv.Quote(filepath.ToSlash(rel)))
+}
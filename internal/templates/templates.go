@@ -0,0 +1,319 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+var (
+	ErrInvalidTemplateName utils.ZestErr = errors.New("invalid template name")
+	ErrInvalidTemplate     utils.ZestErr = errors.New("invalid template")
+	ErrTemplateExists      utils.ZestErr = errors.New("template already exists")
+	ErrTemplateNotExists   utils.ZestErr = errors.New("template does not exist")
+	ErrBuiltinTemplate     utils.ZestErr = errors.New("built-in templates can't be changed")
+	ErrMissingVariable     utils.ZestErr = errors.New("missing template variable")
+)
+
+// allows the same names as workspaces
+var validName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// variable names must be usable as {{ .var }}
+var validVar = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// separator splits the template metadata from the workspace spec body.
+const separator = "\n---\n"
+
+// Variable is a value the user provides when a template is rendered.
+type Variable struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Default     string `yaml:"default,omitempty" json:"default,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required"` // Must be given a value when no default is set
+}
+
+// Template renders a workspace spec from a set of variables.
+// It is stored as a YAML file with the metadata, a `---` line and then the
+// spec body written with text/template, e.g. ~/.zest/templates/<name>.yaml
+type Template struct {
+	Name        string     `yaml:"-"`
+	Path        string     `yaml:"-"` // Path of a user template, empty for built-ins
+	Builtin     bool       `yaml:"-"`
+	Description string     `yaml:"description"`
+	Variables   []Variable `yaml:"variables"`
+
+	body string
+}
+
+// parse reads a template file made of a metadata document and a spec body.
+func parse(name string, data []byte) (*Template, error) {
+	meta, body, found := strings.Cut(strings.ReplaceAll(string(data), "\r\n", "\n"), separator)
+	if !found {
+		return nil, fmt.Errorf("template '%s' is missing the '---' line between metadata and spec", name)
+	}
+
+	tmpl := &Template{Name: name}
+	if err := yaml.Unmarshal([]byte(meta), tmpl); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of template '%s', %v", name, err)
+	}
+	tmpl.body = body
+
+	if err := tmpl.Validate(); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// Validate checks the metadata and that the body is a valid text/template.
+func (t *Template) Validate() error {
+	if strings.TrimSpace(t.Description) == "" {
+		return fmt.Errorf("%w '%s': description is missing", ErrInvalidTemplate, t.Name)
+	}
+
+	seen := map[string]bool{}
+	for _, v := range t.Variables {
+		if !validVar.MatchString(v.Name) {
+			return fmt.Errorf("%w '%s': invalid variable name '%s'", ErrInvalidTemplate, t.Name, v.Name)
+		}
+		if v.Name == "name" {
+			return fmt.Errorf("%w '%s': variable 'name' is reserved for the workspace name", ErrInvalidTemplate, t.Name)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("%w '%s': variable '%s' is declared twice", ErrInvalidTemplate, t.Name, v.Name)
+		}
+		seen[v.Name] = true
+	}
+
+	if _, err := template.New(t.Name).Funcs(funcs).Parse(t.body); err != nil {
+		return fmt.Errorf("%w '%s': %v", ErrInvalidTemplate, t.Name, err)
+	}
+	return nil
+}
+
+// userPath returns where the user template with the given name is stored.
+func userPath(cfg *utils.ZestConfig, name string) string {
+	return filepath.Join(cfg.TemplateDir(), name+".yaml")
+}
+
+// Load returns the template with the given name. User templates in
+// ~/.zest/templates take precedence over built-in ones.
+func Load(cfg *utils.ZestConfig, name string) (*Template, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidTemplateName, name)
+	}
+
+	path := userPath(cfg, name)
+	if data, err := os.ReadFile(path); err == nil {
+		tmpl, err := parse(name, data)
+		if err != nil {
+			return nil, err
+		}
+		tmpl.Path = path
+		return tmpl, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	data, err := builtinFS.ReadFile("builtin/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrTemplateNotExists, name)
+	}
+	tmpl, err := parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Builtin = true
+	return tmpl, nil
+}
+
+// List returns every available template sorted by name, a user template
+// hides the built-in one of the same name. A user template that can't be
+// loaded is skipped with a warning, so one bad file doesn't hide the rest.
+func List(cfg *utils.ZestConfig) ([]*Template, error) {
+	names := map[string]bool{}
+
+	builtins, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range builtins {
+		names[strings.TrimSuffix(entry.Name(), ".yaml")] = true
+	}
+
+	users, err := filepath.Glob(filepath.Join(cfg.TemplateDir(), "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range users {
+		names[strings.TrimSuffix(filepath.Base(path), ".yaml")] = true
+	}
+
+	out := []*Template{}
+	for name := range names {
+		tmpl, err := Load(cfg, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[zest] warning: skipping template '%s', %v\n", name, err)
+			continue
+		}
+		out = append(out, tmpl)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out, nil
+}
+
+// Variable returns the variable with the given name.
+func (t *Template) Variable(name string) (Variable, bool) {
+	for _, v := range t.Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+// Body returns the unrendered spec of the template.
+func (t *Template) Body() string {
+	return t.body
+}
+
+// Bytes returns the template in the format it is stored on disk.
+func (t *Template) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(t); err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata of template '%s', %v", t.Name, err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteString("---\n")
+	buf.WriteString(t.body)
+	return buf.Bytes(), nil
+}
+
+// Save writes the template to ~/.zest/templates/<name>.yaml, an existing
+// user template is only replaced with force.
+func (t *Template) Save(cfg *utils.ZestConfig, force bool) error {
+	if !validName.MatchString(t.Name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidTemplateName, t.Name)
+	}
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	path := userPath(cfg, t.Name)
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%w: '%s'", ErrTemplateExists, t.Name)
+	}
+
+	data, err := t.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write template '%s', %v", t.Name, err)
+	}
+	t.Path = path
+	t.Builtin = false
+	return nil
+}
+
+// Delete removes the user template with the given name.
+func Delete(cfg *utils.ZestConfig, name string) error {
+	tmpl, err := Load(cfg, name)
+	if err != nil {
+		return err
+	}
+	if tmpl.Builtin {
+		return fmt.Errorf("%w: '%s'", ErrBuiltinTemplate, name)
+	}
+	return os.Remove(tmpl.Path)
+}
+
+var funcs = template.FuncMap{
+	// quote renders a value as a double-quoted YAML string
+	"quote": strconv.Quote,
+	// join builds a path below a directory variable
+	"join": filepath.Join,
+	// goos is the system the template is rendered on, for apps that only run on some
+	"goos": func() string { return runtime.GOOS },
+	// split turns a comma-separated value into a trimmed list
+	"split": func(s, sep string) []string {
+		out := []string{}
+		for _, part := range strings.Split(s, sep) {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+		return out
+	},
+}
+
+// Missing returns the required variables that have neither a value in vars
+// nor a default.
+func (t *Template) Missing(vars map[string]string) []Variable {
+	missing := []Variable{}
+	for _, v := range t.Variables {
+		if v.Required && vars[v.Name] == "" && v.Default == "" {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// Render executes the template with vars and returns the workspace spec.
+// Every required variable must have a value, either in vars or as a default.
+func (t *Template) Render(vars map[string]string) ([]byte, error) {
+	if missing := t.Missing(vars); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: '%s' needs a value for '%s'", ErrMissingVariable, t.Name, missing[0].Name)
+	}
+
+	values := map[string]string{}
+	for _, v := range t.Variables {
+		val, ok := vars[v.Name]
+		if !ok {
+			val = v.Default
+		}
+		values[v.Name] = utils.ExpandHome(val)
+	}
+	for k, v := range vars {
+		if _, ok := values[k]; !ok {
+			values[k] = v
+		}
+	}
+
+	tmpl, err := template.New(t.Name).Funcs(funcs).Option("missingkey=error").Parse(t.body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template '%s', %v", t.Name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return nil, fmt.Errorf("failed to render template '%s', %v", t.Name, err)
+	}
+
+	// make sure the result is still a valid workspace spec
+	spec := map[string]any{}
+	if err := yaml.Unmarshal(buf.Bytes(), &spec); err != nil {
+		return nil, fmt.Errorf("template '%s' rendered invalid YAML, %v", t.Name, err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -1,6 +1,12 @@
 package utils
 
-import "strings"
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
 
 func JoinQuoted(args []string) string {
 	quoted := make([]string, len(args))
@@ -9,3 +15,44 @@ func JoinQuoted(args []string) string {
 	}
 	return strings.Join(quoted, ", ")
 }
+
+// BinaryExists reports whether bin resolves to an executable, either as a
+// path on disk or as a name looked up on PATH.
+func BinaryExists(bin string) bool {
+	if filepath.IsAbs(bin) || strings.ContainsRune(bin, filepath.Separator) {
+		_, err := os.Stat(bin)
+		return err == nil
+	}
+	_, err := exec.LookPath(bin)
+	return err == nil
+}
+
+// Environ returns the environment of zest with the layers set on top of it,
+// later layers win. Keys of a layer are added in order so the result is
+// stable.
+func Environ(layers ...map[string]string) []string {
+	env := os.Environ()
+	for _, vars := range layers {
+		keys := make([]string, 0, len(vars))
+		for k := range vars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			env = append(env, k+"="+vars[k])
+		}
+	}
+	return env
+}
+
+// ExpandHome replaces a leading ~ in path with the user's home directory.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
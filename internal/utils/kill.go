@@ -15,6 +15,11 @@ func killWithSyscall(pid int) error {
 	return err
 }
 
+// terminate asks the process to exit, letting it clean up first.
+func terminate(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
+
 func Kill(pid int) error {
 	return killWithSyscall(pid)
 }
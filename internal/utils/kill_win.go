@@ -25,6 +25,11 @@ func killWithTaskkill(pid int) error {
 	return err
 }
 
+// terminate asks the process tree to close its windows and exit.
+func terminate(pid int) error {
+	return exec.Command("taskkill", "/PID", strconv.Itoa(pid), "/T").Run()
+}
+
 func Kill(pid int) error {
 	return killWithTaskkill(pid)
 }
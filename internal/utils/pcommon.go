@@ -63,3 +63,47 @@ func WaitForNewPIDs(name string, before []int, maxWait time.Duration) ([]int, er
 	}
 	return nil, errors.New("timed out waiting for new processes")
 }
+
+// IsRunning reports whether a process with the given pid is alive.
+func IsRunning(pid int) bool {
+	ok, err := process.PidExists(int32(pid))
+	return err == nil && ok
+}
+
+// Stop asks every process to exit and kills the ones still running once
+// grace has elapsed. A zero grace kills them right away. It returns the
+// error for each pid that could not be killed.
+func Stop(pids []int, grace time.Duration) map[int]error {
+	if grace > 0 {
+		for _, pid := range pids {
+			if IsRunning(pid) {
+				terminate(pid)
+			}
+		}
+
+		deadline := time.Now().Add(grace)
+		for time.Now().Before(deadline) && anyRunning(pids) {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	errs := map[int]error{}
+	for _, pid := range pids {
+		if grace > 0 && !IsRunning(pid) {
+			continue
+		}
+		if err := Kill(pid); err != nil {
+			errs[pid] = err
+		}
+	}
+	return errs
+}
+
+func anyRunning(pids []int) bool {
+	for _, pid := range pids {
+		if IsRunning(pid) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// Settings holds the global zest configuration.
+// This is read from ~/.zest/zest.yaml, every key can be overridden with a
+// ZEST_* environment variable (e.g. ZEST_LAUNCH_PARALLELISM=4).
+type Settings struct {
+	Launch LaunchSettings `mapstructure:"launch"`
+	Close  CloseSettings  `mapstructure:"close"`
+	List   ListSettings   `mapstructure:"list"`
+
+	Browser  string `mapstructure:"browser"`  // Backend used by `browser` apps (e.g. brave)
+	Terminal string `mapstructure:"terminal"` // Backend used by `terminal` apps (e.g. powershell)
+	Editor   string `mapstructure:"editor"`   // Backend used by `editor` apps (e.g. neovim)
+	Output   string `mapstructure:"output"`   // Preferred output format: table or json
+
+	Env      map[string]string `mapstructure:"env"`      // Environment applied to every workspace, below the workspace env
+	Binaries map[string]string `mapstructure:"binaries"` // Path to the executable per app type (e.g. sioyek: /opt/sioyek/sioyek)
+}
+
+type LaunchSettings struct {
+	Parallelism int  `mapstructure:"parallelism"` // Number of apps started at the same time
+	Watch       bool `mapstructure:"watch"`       // Keep watching the workspace files after a launch, see `zest launch --watch`
+}
+
+type CloseSettings struct {
+	GracePeriod time.Duration `mapstructure:"grace_period"` // Time given to apps to exit before they are killed
+}
+
+type ListSettings struct {
+	Sort   string `mapstructure:"sort"`   // Default sort key for `zest list`
+	Filter string `mapstructure:"filter"` // Default status filter for `zest list`
+}
+
+// DefaultSettings returns the settings used when zest.yaml does not set a value.
+func DefaultSettings() Settings {
+	return Settings{
+		Launch:   LaunchSettings{Parallelism: 1},
+		Close:    CloseSettings{GracePeriod: 5 * time.Second},
+		List:     ListSettings{Sort: "name", Filter: "all"},
+		Browser:  "brave",
+		Terminal: "powershell",
+		Editor:   "vscode",
+		Output:   "table",
+		Env:      map[string]string{},
+		Binaries: map[string]string{},
+	}
+}
+
+// Validate reports the first setting that holds an unsupported value.
+func (s Settings) Validate() error {
+	if s.Launch.Parallelism < 1 {
+		return fmt.Errorf("launch.parallelism must be at least 1, got %d", s.Launch.Parallelism)
+	}
+	if s.Close.GracePeriod < 0 {
+		return fmt.Errorf("close.grace_period must not be negative, got %v", s.Close.GracePeriod)
+	}
+	switch s.Output {
+	case "table", "json":
+	default:
+		return fmt.Errorf("output must be table or json, got '%s'", s.Output)
+	}
+	return nil
+}
+
+// JSONOutput reports whether commands should print JSON unless told otherwise.
+func (s Settings) JSONOutput() bool {
+	return s.Output == "json"
+}
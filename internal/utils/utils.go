@@ -9,6 +9,9 @@ type ZestErr error
 
 type ZestConfig struct {
 	ZestDir string // Root path to zest directory (default is $HOME)
+
+	ConfigFile string   // Path of the global config file in use, empty if none was found
+	Settings   Settings // Global configuration loaded from zest.yaml
 }
 
 // Root zest directory
@@ -25,6 +28,16 @@ func (cfg *ZestConfig) WspDir() string {
 	return filepath.Join(cfg.RootDir(), "workspaces")
 }
 
+// Directory containing user templates
+func (cfg *ZestConfig) TemplateDir() string {
+	return filepath.Join(cfg.RootDir(), "templates")
+}
+
+// Directory holding the git worktrees of workspaces
+func (cfg *ZestConfig) WorktreeDir() string {
+	return filepath.Join(cfg.RootDir(), "worktrees")
+}
+
 // Directory storing state
 func (cfg *ZestConfig) StateDir() string {
 	return filepath.Join(cfg.RootDir(), "state")
@@ -35,13 +48,25 @@ func (cfg *ZestConfig) RuntimeWspDir() string {
 	return filepath.Join(cfg.StateDir(), "workspaces")
 }
 
+// Directory storing the session logs of workspaces
+func (cfg *ZestConfig) LogDir() string {
+	return filepath.Join(cfg.StateDir(), "logs")
+}
+
+// Directory storing the .code-workspace files generated for vscode apps
+func (cfg *ZestConfig) CodeWorkspaceDir() string {
+	return filepath.Join(cfg.StateDir(), "code-workspaces")
+}
+
 // Ensures all necessary directories exist
 func (cfg *ZestConfig) EnsureDirs() error {
 	dirs := []string{
 		cfg.RootDir(),
 		cfg.WspDir(),
+		cfg.TemplateDir(),
 		cfg.StateDir(),
 		cfg.RuntimeWspDir(),
+		cfg.LogDir(),
 	}
 
 	for _, dir := range dirs {
@@ -0,0 +1,86 @@
+package utils
+
+import "gopkg.in/yaml.v3"
+
+// MappingValue returns the value node for key in a YAML mapping node.
+func MappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// RemoveMappingKey deletes key from a YAML mapping node and returns its value.
+func RemoveMappingKey(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			val := m.Content[i+1]
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return val
+		}
+	}
+	return nil
+}
+
+// SetMappingPath sets the value at the nested keys of a YAML mapping node,
+// creating intermediate mappings as needed. Existing comments are kept.
+func SetMappingPath(m *yaml.Node, keys []string, value *yaml.Node) {
+	for i, key := range keys {
+		last := i == len(keys)-1
+		child := MappingValue(m, key)
+
+		switch {
+		case last && child != nil:
+			// keep the comments attached to the old value
+			value.HeadComment = child.HeadComment
+			value.LineComment = child.LineComment
+			value.FootComment = child.FootComment
+			*child = *value
+		case last:
+			m.Content = append(m.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				value,
+			)
+		case child == nil || child.Kind != yaml.MappingNode:
+			next := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if child != nil {
+				*child = *next
+				next = child
+			} else {
+				m.Content = append(m.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+					next,
+				)
+			}
+			m = next
+		default:
+			m = child
+		}
+	}
+}
+
+// UnsetMappingPath removes the value at the nested keys of a YAML mapping
+// node, dropping parent mappings that end up empty. It reports whether
+// anything was removed.
+func UnsetMappingPath(m *yaml.Node, keys []string) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	if len(keys) == 1 {
+		return RemoveMappingKey(m, keys[0]) != nil
+	}
+
+	child := MappingValue(m, keys[0])
+	if child == nil || child.Kind != yaml.MappingNode {
+		return false
+	}
+	if !UnsetMappingPath(child, keys[1:]) {
+		return false
+	}
+	if len(child.Content) == 0 {
+		RemoveMappingKey(m, keys[0])
+	}
+	return true
+}
@@ -26,31 +26,31 @@ var (
 	ErrWorkspaceNotExists   utils.ZestErr = errors.New("workspace does not exist")
 )
 
-// WspConfig defines the user-provided configuration for a workspace.
-// This is stored as a YAML file at ~/.zest/workspaces/<name>.yaml
+// WspConfig holds the zest-managed metadata of a workspace.
+// This is stored only in the registry, the user-editable part lives in `WspSpec`.
 type WspConfig struct {
-	Name   string `json:"name" yaml:"name"`     // Name of the workspace
-	Path   string `json:"path" yaml:"path"`     // Absolute path to the editable workspace config file
-	Status Status `json:"status" yaml:"status"` // Current status of the workspace (e.g., Active, Inactive)
+	Name   string `json:"name"`   // Name of the workspace
+	Path   string `json:"path"`   // Absolute path to the editable workspace config file
+	Status Status `json:"status"` // Current status of the workspace (e.g., Active, Inactive)
 
-	WorkspaceDir string `json:"workspace_dir" yaml:"workspace_dir"` // Root directory where all commands will be executed
+	Template string `json:"template,omitempty"` // Optional template name this workspace is based on
+	Project  string `json:"project,omitempty"`  // Project file the workspace was registered from, see `RegisterProject`
 
-	Template string `json:"template,omitempty" yaml:"template,omitempty"` // Optional template name this workspace is based on
-
-	Created     string `json:"created" yaml:"created"`           // Timestamp of when the config file was created (RFC3339 format)
-	LastUpdated string `json:"last_updated" yaml:"last_updated"` // Timestamp of the last modification to the config file
-	LastUsed    string `json:"last_used" yaml:"last_used"`       // Timestamp of the most recent launch via `zest launch`
+	Created     string `json:"created"`      // Timestamp of when the config file was created (RFC3339 format)
+	LastUpdated string `json:"last_updated"` // Timestamp of the last modification to the config file
+	LastUsed    string `json:"last_used"`    // Timestamp of the most recent launch via `zest launch`
 }
 
+// allows alphanumeric names for workspaces
+var validName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
 // returns true if workspace with the given name already exists
 func checkName(cfg *utils.ZestConfig, name string, force bool) error {
 	if strings.TrimSpace(name) == "" {
 		return ErrInvalidWorkspaceName
 	}
 
-	// allows alphanumeric names for workspaces
-	reg := regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
-	if !reg.MatchString(name) {
+	if !validName.MatchString(name) {
 		return ErrInvalidWorkspaceName
 	}
 
@@ -64,7 +64,18 @@ func checkName(cfg *utils.ZestConfig, name string, force bool) error {
 	return ErrWorkspaceExists
 }
 
+// Init creates a workspace with an empty spec.
 func Init(cfg *utils.ZestConfig, name, template string, force bool) error {
+	data, err := yaml.Marshal(NewWspSpec(name))
+	if err != nil {
+		return fmt.Errorf("failed to marshal yaml file, %s", err)
+	}
+	return InitWithSpec(cfg, name, template, data, force)
+}
+
+// InitWithSpec creates a workspace whose YAML file holds the given spec,
+// e.g. one rendered from a template.
+func InitWithSpec(cfg *utils.ZestConfig, name, template string, data []byte, force bool) error {
 	if err := checkName(cfg, name, force); err != nil {
 		return err
 	}
@@ -84,19 +95,16 @@ func Init(cfg *utils.ZestConfig, name, template string, force bool) error {
 	}
 	wspCfg.LastUpdated = wspCfg.Created
 
-	data, err := yaml.Marshal(wspCfg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal yaml file, %s", err)
-	}
-
 	// write user editable workspace config file
 	if err := os.WriteFile(wspCfg.Path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write to worksapce config file at %v, %v", wspCfg.Path, err)
 	}
 
-	reg.Update(&wspCfg)
-
-	if err := reg.Save(); err != nil {
+	err = reg.Transact(func() error {
+		reg.Update(&wspCfg)
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save workspace config, %v", err)
 	}
 
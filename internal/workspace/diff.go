@@ -0,0 +1,63 @@
+package workspace
+
+import (
+	"slices"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+)
+
+// AppDiff lists how the running apps of a workspace differ from its spec.
+type AppDiff struct {
+	Added   []string `json:"added"`   // Apps the spec selects that are not running, unless they were stopped
+	Removed []string `json:"removed"` // Running apps the spec no longer has
+	Changed []string `json:"changed"` // Running apps whose config changed since they were started
+	Env     []string `json:"env"`     // Running apps whose config is the same but whose environment changed
+}
+
+func (d AppDiff) IsEmpty() bool {
+	return d.Apps() == 0
+}
+
+// Apps returns the number of apps that would be started, stopped or restarted.
+func (d AppDiff) Apps() int {
+	return len(d.Added) + len(d.Removed) + len(d.Changed) + len(d.Env)
+}
+
+// Restarted returns the running apps that would be restarted, for a changed
+// config or a changed environment.
+func (d AppDiff) Restarted() []string {
+	return append(append([]string{}, d.Changed...), d.Env...)
+}
+
+// Diff compares the running apps with plan, which should be built with the
+// profile and env the workspace was launched with. Apps are matched by id.
+// Apps started before hashes were recorded count as changed, apps stopped
+// with `zest app stop` are not added back. Apps started
+// with another environment than plan has are listed on their own.
+func (wspRt *WspRuntime) Diff(plan *launch.Plan) (AppDiff, error) {
+	diff := AppDiff{Added: []string{}, Removed: []string{}, Changed: []string{}, Env: []string{}}
+	if len(wspRt.Apps) != len(wspRt.PIDs) {
+		return diff, ErrUntrackedApps
+	}
+
+	for _, app := range plan.Apps {
+		id := app.Meta().ID
+		if wspRt.FindApp(id) >= 0 {
+			if wspRt.Hashes[id] != plan.Hash(app) {
+				diff.Changed = append(diff.Changed, id)
+			} else if env := wspRt.EnvHashes[id]; env != "" && env != plan.EnvHash() {
+				diff.Env = append(diff.Env, id)
+			}
+		} else if wspRt.Launch.Filter.Selects(app.Meta()) && !slices.Contains(wspRt.Stopped, id) {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+
+	for _, id := range wspRt.Apps {
+		if _, ok := plan.App(id); !ok {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+
+	return diff, nil
+}
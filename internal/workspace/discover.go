@@ -0,0 +1,349 @@
+package workspace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// projectMarkers are the files and directories that make a directory the
+// root of a project, besides a *.code-workspace file.
+var projectMarkers = []string{".git", "go.mod", "package.json", "pyproject.toml"}
+
+// skipDirs are never searched for projects.
+var skipDirs = map[string]bool{"node_modules": true, "vendor": true}
+
+// Project is a project root found by `Discover`.
+type Project struct {
+	Name    string   `json:"name"`             // Name of the workspace for the project
+	Dir     string   `json:"dir"`              // Root directory of the project
+	Markers []string `json:"markers"`          // Markers the root was found by, e.g. go.mod
+	Remote  string   `json:"remote,omitempty"` // Web URL of the git remote
+	Skip    string   `json:"skip,omitempty"`   // Why no workspace is created, empty if one is
+
+	Spec *WspSpec `json:"-"` // Spec of the workspace that would be created
+}
+
+// Discover finds the projects in root and the directories up to depth levels
+// below it. The directories of a project are not searched further. Projects
+// that already have a workspace, or carry a project file of their own, are
+// returned with a reason to skip them.
+func Discover(cfg *utils.ZestConfig, root string, depth int) ([]*Project, error) {
+	root, err := filepath.Abs(utils.ExpandHome(root))
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(root); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	reg, err := NewWspRegistry(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load registry, %v", err)
+	}
+	taken := map[string]bool{}
+	known := map[string]string{} // workspace_dir -> workspace
+	for _, name := range reg.GetNames() {
+		taken[name] = true
+		plan, err := launch.NewLaunchPlan(cfg, name, "")
+		if err != nil || plan.WorkingDir == "" {
+			continue
+		}
+		known[realPath(utils.ExpandHome(plan.WorkingDir))] = name
+	}
+
+	projects := []*Project{}
+	var walk func(dir string, level int) error
+	walk = func(dir string, level int) error {
+		entries, err := os.ReadDir(dir)
+		if err != nil && dir == root {
+			return err
+		} else if err != nil {
+			// one unreadable directory shouldn't stop the scan
+			fmt.Fprintf(os.Stderr, "[zest] warning: skipping %v\n", err)
+			return nil
+		}
+
+		if project := newProject(dir, entries); project != nil {
+			projects = append(projects, project)
+			return nil
+		}
+		if level >= depth {
+			return nil
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() || strings.HasPrefix(name, ".") || skipDirs[name] {
+				continue
+			}
+			if err := walk(filepath.Join(dir, name), level+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root, 0); err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if name, ok := known[realPath(project.Dir)]; ok {
+			project.Skip = fmt.Sprintf("already workspace '%s'", name)
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(project.Dir, launch.ProjectFile)); err == nil {
+			project.Skip = fmt.Sprintf("has a %s, run 'zest launch' in it", launch.ProjectFile)
+			continue
+		}
+
+		project.Name = uniqueName(project.Dir, taken)
+		if project.Name == "" {
+			project.Skip = "no free workspace name"
+			continue
+		}
+		taken[project.Name] = true
+		project.Spec = project.spec(cfg.Settings)
+	}
+
+	return projects, nil
+}
+
+// CreateProjects creates the workspaces of the projects that aren't skipped.
+func CreateProjects(cfg *utils.ZestConfig, projects []*Project) error {
+	for _, project := range projects {
+		if project.Skip != "" {
+			continue
+		}
+
+		data, err := yaml.Marshal(project.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to marshal yaml file, %s", err)
+		}
+		if err := InitWithSpec(cfg, project.Name, "", data, false); err != nil {
+			return fmt.Errorf("failed to create workspace '%s', %v", project.Name, err)
+		}
+	}
+	return nil
+}
+
+// newProject returns the project rooted at dir, or nil if none of entries
+// marks it as a project root.
+func newProject(dir string, entries []os.DirEntry) *Project {
+	present := map[string]bool{}
+	codeWorkspace := ""
+	for _, entry := range entries {
+		present[entry.Name()] = true
+		if codeWorkspace == "" && strings.HasSuffix(entry.Name(), ".code-workspace") && !entry.IsDir() {
+			codeWorkspace = entry.Name()
+		}
+	}
+
+	project := &Project{Dir: dir, Markers: []string{}}
+	for _, marker := range projectMarkers {
+		if present[marker] {
+			project.Markers = append(project.Markers, marker)
+		}
+	}
+	if codeWorkspace != "" {
+		project.Markers = append(project.Markers, codeWorkspace)
+	}
+	if len(project.Markers) == 0 {
+		return nil
+	}
+
+	if present[".git"] {
+		project.Remote = gitRemoteURL(filepath.Join(dir, ".git"))
+	}
+	return project
+}
+
+// uniqueName returns a workspace name for dir that isn't taken, from the
+// directory name or, when that is taken, its parent and the directory name.
+func uniqueName(dir string, taken map[string]bool) string {
+	base := invalidNameChars.ReplaceAllString(filepath.Base(dir), "-")
+	parent := invalidNameChars.ReplaceAllString(filepath.Base(filepath.Dir(dir)), "-")
+
+	for _, name := range []string{base, parent + "-" + base} {
+		name = strings.TrimLeft(name, "_-")
+		if name != "" && validName.MatchString(name) && !taken[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// spec infers the apps of the project: the configured editor on its root, a
+// terminal in its root and the web page of its git remote in the browser.
+// VS Code opens the .code-workspace file of the project instead, if it has
+// one. The terminal is left out when the configured one doesn't run on this
+// system.
+func (p *Project) spec(settings utils.Settings) *WspSpec {
+	spec := NewWspSpec(p.Name)
+	spec.WorkspaceDir = p.Dir
+
+	editorPath := p.Dir
+	for _, marker := range p.Markers {
+		if strings.HasSuffix(marker, ".code-workspace") && settings.Editor == "vscode" {
+			editorPath = filepath.Join(p.Dir, marker)
+		}
+	}
+	spec.Apps["editor"] = []map[string]any{{"path": editorPath}}
+
+	if launch.Supported(settings.Terminal) {
+		spec.Apps["terminal"] = []map[string]any{{"tabs": p.tabs()}}
+	}
+
+	if p.Remote != "" {
+		spec.Apps["browser"] = []map[string]any{{"tabs": []string{p.Remote}}}
+	}
+	return spec
+}
+
+// tabs returns the commands the terminal of the project opens with.
+func (p *Project) tabs() []string {
+	tabs := []string{}
+	for _, marker := range p.Markers {
+		switch marker {
+		case ".git":
+			tabs = append(tabs, "git status")
+		case "go.mod":
+			tabs = append(tabs, "go build ./...")
+		case "package.json":
+			if script := npmScript(p.Dir); script != "" {
+				tabs = append(tabs, "npm run "+script)
+			}
+		}
+	}
+	return tabs
+}
+
+// npmScript returns the script of package.json that starts the project.
+func npmScript(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	pkg := struct {
+		Scripts map[string]string `json:"scripts"`
+	}{}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	for _, script := range []string{"dev", "start"} {
+		if _, ok := pkg.Scripts[script]; ok {
+			return script
+		}
+	}
+	return ""
+}
+
+// remoteSection matches the section header of a remote in a git config.
+var remoteSection = regexp.MustCompile(`^\[remote "([^"]+)"\]$`)
+
+// gitRemoteURL returns the web page of the origin remote of the repository
+// at gitDir, or of its first remote. gitDir may be the `.git` file of a
+// worktree or submodule.
+func gitRemoteURL(gitDir string) string {
+	if data, err := os.ReadFile(gitDir); err == nil {
+		dir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+		if !ok {
+			return ""
+		}
+		dir = strings.TrimSpace(dir)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(gitDir), dir)
+		}
+		gitDir = dir
+		// worktrees share the config of the main repository
+		if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+			gitDir = filepath.Join(gitDir, strings.TrimSpace(string(common)))
+		}
+	}
+
+	f, err := os.Open(filepath.Join(gitDir, "config"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	remotes := map[string]string{}
+	names := []string{}
+	current := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			current = ""
+			if m := remoteSection.FindStringSubmatch(line); m != nil {
+				current = m[1]
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if current == "" || !ok || strings.TrimSpace(key) != "url" {
+			continue
+		}
+		if _, seen := remotes[current]; !seen {
+			names = append(names, current)
+			remotes[current] = strings.TrimSpace(value)
+		}
+	}
+
+	if url, ok := remotes["origin"]; ok {
+		return webURL(url)
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		return webURL(remotes[names[0]])
+	}
+	return ""
+}
+
+// scpRemote matches remotes written as user@host:owner/repo.
+var scpRemote = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// webURL turns the url of a git remote into the web page of the repository,
+// e.g. git@github.com:owner/repo.git into https://github.com/owner/repo.
+// Remotes on disk give an empty string.
+func webURL(remote string) string {
+	web := "https"
+	var host, path string
+	if scheme, rest, ok := strings.Cut(remote, "://"); ok {
+		switch scheme {
+		case "http":
+			web = scheme
+		case "https", "ssh", "git":
+		default:
+			return ""
+		}
+		host, path, _ = strings.Cut(rest, "/")
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+		if scheme == "ssh" || scheme == "git" {
+			host, _, _ = strings.Cut(host, ":") // ssh ports aren't web ports
+		}
+	} else if m := scpRemote.FindStringSubmatch(remote); m != nil && len(m[1]) > 1 {
+		host, path = m[1], m[2]
+	} else {
+		return ""
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return ""
+	}
+	return web + "://" + host + "/" + path
+}
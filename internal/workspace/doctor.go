@@ -0,0 +1,312 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+type IssueKind string
+
+var (
+	OrphanSpec     IssueKind = "orphan-spec"     // config file with no registry entry
+	DanglingEntry  IssueKind = "dangling-entry"  // registry entry with no config file
+	StaleRuntime   IssueKind = "stale-runtime"   // runtime file for an inactive or unknown workspace
+	MissingRuntime IssueKind = "missing-runtime" // active workspace with no runtime file
+	DeadPIDs       IssueKind = "dead-pids"       // runtime records processes that have exited
+	InvalidSpec    IssueKind = "invalid-spec"    // config file that can't be turned into a launch plan
+	MissingBinary  IssueKind = "missing-binary"  // app executable not found on PATH or disk
+	UnsupportedApp IssueKind = "unsupported-app" // app backend that doesn't run on this system
+)
+
+// Issue is a single inconsistency between the registry, the workspace
+// config files and the runtime state.
+type Issue struct {
+	Kind      IssueKind `json:"kind"`
+	Workspace string    `json:"workspace"`
+	Detail    string    `json:"detail"`
+	Fixable   bool      `json:"fixable"`
+}
+
+// Diagnose inspects the zest directory and returns every issue it finds.
+func Diagnose(cfg *utils.ZestConfig) ([]Issue, error) {
+	reg, err := NewWspRegistry(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace registry, %v", err)
+	}
+
+	issues := []Issue{}
+
+	specs, err := filepath.Glob(filepath.Join(cfg.WspDir(), "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range specs {
+		name := strings.TrimSuffix(filepath.Base(path), ".yaml")
+		if reg.Exists(name) {
+			continue
+		}
+		issues = append(issues, Issue{
+			Kind:      OrphanSpec,
+			Workspace: name,
+			Detail:    "config file is not registered: " + path,
+			Fixable:   validName.MatchString(name),
+		})
+	}
+
+	names := reg.GetNames()
+	sort.Strings(names)
+	for _, name := range names {
+		wspCfg, _ := reg.GetCfg(name)
+		if _, err := os.Stat(wspCfg.Path); errors.Is(err, os.ErrNotExist) {
+			issues = append(issues, Issue{
+				Kind:      DanglingEntry,
+				Workspace: name,
+				Detail:    "config file is missing: " + wspCfg.Path,
+				Fixable:   true,
+			})
+			continue
+		}
+
+		issues = append(issues, diagnoseRuntime(cfg, wspCfg)...)
+		issues = append(issues, diagnoseApps(cfg, name)...)
+	}
+
+	rtFiles, err := filepath.Glob(filepath.Join(cfg.RuntimeWspDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range rtFiles {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		if wspCfg, ok := reg.GetCfg(name); ok && wspCfg.Status == Active {
+			continue
+		}
+		issues = append(issues, Issue{
+			Kind:      StaleRuntime,
+			Workspace: name,
+			Detail:    "runtime file for an inactive or unknown workspace: " + path,
+			Fixable:   true,
+		})
+	}
+
+	return issues, nil
+}
+
+func diagnoseRuntime(cfg *utils.ZestConfig, wspCfg *WspConfig) []Issue {
+	if wspCfg.Status != Active {
+		return nil
+	}
+
+	rt, err := NewWspRuntime(cfg, wspCfg.Name)
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(rt.RtFile); errors.Is(err, os.ErrNotExist) {
+		return []Issue{{
+			Kind:      MissingRuntime,
+			Workspace: wspCfg.Name,
+			Detail:    "workspace is marked active but has no runtime file",
+			Fixable:   true,
+		}}
+	}
+	if err := rt.Load(); err != nil {
+		return []Issue{{
+			Kind:      StaleRuntime,
+			Workspace: wspCfg.Name,
+			Detail:    fmt.Sprintf("runtime file is unreadable, %v", err),
+			Fixable:   true,
+		}}
+	}
+
+	total, dead := 0, 0
+	for _, pids := range rt.PIDs {
+		for _, pid := range pids {
+			total++
+			if !utils.IsRunning(pid) {
+				dead++
+			}
+		}
+	}
+	if dead == 0 {
+		return nil
+	}
+
+	return []Issue{{
+		Kind:      DeadPIDs,
+		Workspace: wspCfg.Name,
+		Detail:    fmt.Sprintf("%d of %d recorded processes are no longer running", dead, total),
+		Fixable:   true,
+	}}
+}
+
+func diagnoseApps(cfg *utils.ZestConfig, name string) []Issue {
+	plan, err := launch.NewLaunchPlan(cfg, name, "")
+	if err != nil {
+		return []Issue{{
+			Kind:      InvalidSpec,
+			Workspace: name,
+			Detail:    err.Error(),
+		}}
+	}
+
+	issues := []Issue{}
+	seen := map[string]bool{}
+	for _, app := range plan.Apps {
+		// its binary only exists where it runs
+		if backend := app.Meta().Backend(); !launch.Supported(backend) {
+			if !seen[backend] {
+				issues = append(issues, Issue{
+					Kind:      UnsupportedApp,
+					Workspace: name,
+					Detail:    fmt.Sprintf("%s: %s is unsupported on %s", app.Meta().ID, backend, runtime.GOOS),
+				})
+			}
+			seen[backend] = true
+			continue
+		}
+
+		bin := app.GetBinary()
+		if bin == "" || seen[bin] {
+			continue
+		}
+		seen[bin] = true
+
+		if !utils.BinaryExists(bin) {
+			issues = append(issues, Issue{
+				Kind:      MissingBinary,
+				Workspace: name,
+				Detail:    fmt.Sprintf("%s: executable '%s' not found", app.GetName(), bin),
+			})
+		}
+	}
+	return issues
+}
+
+// Repair fixes every fixable issue and returns the ones it fixed.
+func Repair(cfg *utils.ZestConfig, issues []Issue) ([]Issue, error) {
+	reg, err := NewWspRegistry(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace registry, %v", err)
+	}
+
+	fixed := []Issue{}
+	err = reg.Transact(func() error {
+		for _, issue := range issues {
+			if !issue.Fixable {
+				continue
+			}
+			if err := reg.repair(cfg, issue); err != nil {
+				return fmt.Errorf("failed to fix %v for '%v', %v", issue.Kind, issue.Workspace, err)
+			}
+			fixed = append(fixed, issue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fixed, nil
+}
+
+// repair fixes a single issue, the caller must hold the registry lock.
+func (wr *WspRegistry) repair(cfg *utils.ZestConfig, issue Issue) error {
+	name := issue.Workspace
+
+	switch issue.Kind {
+	case OrphanSpec:
+		path := filepath.Join(cfg.WspDir(), name+".yaml")
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		modified := info.ModTime().Format(time.RFC3339)
+		wr.Update(&WspConfig{
+			Name:        name,
+			Path:        path,
+			Status:      Inactive,
+			Created:     modified,
+			LastUpdated: modified,
+			LastUsed:    "never",
+		})
+
+	case DanglingEntry:
+		// the apps of an active workspace would be orphaned with its runtime
+		if wspCfg, ok := wr.GetCfg(name); ok && wspCfg.Status == Active {
+			if err := stopRuntime(cfg, name); err != nil {
+				return err
+			}
+		}
+		wr.Unregister(name)
+		return removeRuntime(cfg, name)
+
+	case StaleRuntime, MissingRuntime:
+		if wspCfg, ok := wr.GetCfg(name); ok {
+			wspCfg.Status = Inactive
+		}
+		return removeRuntime(cfg, name)
+
+	case DeadPIDs:
+		rt, err := NewWspRuntime(cfg, name)
+		if err != nil {
+			return err
+		}
+
+		alive := 0
+		err = rt.Transact(func() error {
+			alive = rt.PruneDead()
+			return nil
+		})
+		if err != nil || alive > 0 {
+			return err
+		}
+
+		// nothing left running, the workspace is effectively closed
+		if wspCfg, ok := wr.GetCfg(name); ok {
+			wspCfg.Status = Inactive
+			wspCfg.LastUsed = time.Now().Format(time.RFC3339)
+		}
+		return removeRuntime(cfg, name)
+	}
+
+	return nil
+}
+
+// stopRuntime stops the processes recorded in the runtime of a workspace.
+func stopRuntime(cfg *utils.ZestConfig, name string) error {
+	rt, err := NewWspRuntime(cfg, name)
+	if err != nil {
+		return err
+	}
+	if err := rt.Load(); err != nil {
+		return err
+	}
+
+	pids := []int{}
+	for _, p := range rt.PIDs {
+		pids = append(pids, p...)
+	}
+	for pid, err := range utils.Stop(pids, cfg.Settings.Close.GracePeriod) {
+		fmt.Fprintf(os.Stderr, "[zest] warning: failed to kill PID %d of '%s', %v\n", pid, name, err)
+	}
+	return nil
+}
+
+func removeRuntime(cfg *utils.ZestConfig, name string) error {
+	rt, err := NewWspRuntime(cfg, name)
+	if err != nil {
+		return err
+	}
+	if err := rt.Delete(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ImportCodeWorkspace creates a workspace from the VS Code .code-workspace
+// file at path and returns its name. name defaults to the name of the file.
+// The workspace opens the folders of the file in one vscode app, with its
+// settings and extension recommendations, and its workspace_dir is the first
+// folder. Remote folders, given by uri, are left out.
+func ImportCodeWorkspace(cfg *utils.ZestConfig, path, name string, force bool) (string, error) {
+	path, err := filepath.Abs(utils.ExpandHome(path))
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s, %v", path, err)
+	}
+	ws, err := launch.ParseCodeWorkspace(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s, %v", path, err)
+	}
+
+	if name == "" {
+		base := strings.TrimSuffix(filepath.Base(path), ".code-workspace")
+		name = strings.TrimLeft(invalidNameChars.ReplaceAllString(base, "-"), "_-")
+	}
+
+	folders := []map[string]any{}
+	for _, folder := range ws.Folders {
+		if folder.Path == "" {
+			continue
+		}
+		dir := utils.ExpandHome(filepath.FromSlash(folder.Path))
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(path), dir)
+		}
+		entry := map[string]any{"path": filepath.Clean(dir)}
+		if folder.Name != "" {
+			entry["name"] = folder.Name
+		}
+		folders = append(folders, entry)
+	}
+	if len(folders) == 0 {
+		return "", fmt.Errorf("%s has no folders on this machine", path)
+	}
+
+	app := map[string]any{"folders": folders}
+	if len(ws.Settings) > 0 {
+		app["settings"] = ws.Settings
+	}
+	if ws.Extensions != nil && len(ws.Extensions.Recommendations) > 0 {
+		app["extensions"] = ws.Extensions.Recommendations
+	}
+
+	spec := NewWspSpec(name)
+	spec.WorkspaceDir = folders[0]["path"].(string)
+	spec.Apps["vscode"] = []map[string]any{app}
+
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal yaml file, %s", err)
+	}
+	if err := InitWithSpec(cfg, name, "", out, force); err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,78 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+// MatchDir returns the workspace whose workspace_dir holds dir. When several
+// do, the one with the longest workspace_dir wins, so a workspace for a repo
+// beats one for the directory of all repos. It returns nil if none matches.
+// Only the workspace_dir of each spec is read, a spec that can't be resolved
+// is skipped with a warning.
+func MatchDir(cfg *utils.ZestConfig, dir string) (*WspConfig, error) {
+	reg, err := NewWspRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	dir = realPath(dir)
+
+	names := reg.GetNames()
+	sort.Strings(names)
+
+	var best *WspConfig
+	bestLen := -1
+	for _, name := range names {
+		root, err := launch.WorkspaceDir(cfg, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[zest] warning: skipping workspace '%s', %v\n", name, err)
+			continue
+		}
+		if root == "" {
+			continue
+		}
+
+		root = utils.ExpandHome(root)
+		if !filepath.IsAbs(root) {
+			continue
+		}
+		root = realPath(root)
+
+		// on a tie the first name wins, so the choice is stable
+		if !withinDir(root, dir) || len(root) <= bestLen {
+			continue
+		}
+		wspCfg, _ := reg.GetCfg(name)
+		best, bestLen = wspCfg, len(root)
+	}
+	return best, nil
+}
+
+// realPath resolves symlinks in path where it can, so /tmp and /private/tmp
+// on macOS compare equal.
+func realPath(path string) string {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		return real
+	}
+	return filepath.Clean(path)
+}
+
+// withinDir reports whether path is root or lies below it.
+func withinDir(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,108 @@
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+// SchemaVersion is the current version of the registry and runtime state files.
+// Bump it together with a new entry in registryMigrations and runtimeMigrations.
+const SchemaVersion = 1
+
+var ErrSchemaTooNew utils.ZestErr = errors.New("state file was written by a newer version of zest")
+
+// migration upgrades a decoded state document from version From to From+1.
+type migration struct {
+	From  int
+	Apply func(doc map[string]any) error
+}
+
+var registryMigrations = []migration{
+	{
+		// v0 kept the user-editable workspace_dir in every registry entry
+		From: 0,
+		Apply: func(doc map[string]any) error {
+			wsps, _ := doc["workspaces"].(map[string]any)
+			for _, entry := range wsps {
+				if m, ok := entry.(map[string]any); ok {
+					delete(m, "workspace_dir")
+				}
+			}
+			return nil
+		},
+	},
+}
+
+var runtimeMigrations = []migration{
+	{
+		// v0 had the same layout, it only lacked the version field
+		From:  0,
+		Apply: func(doc map[string]any) error { return nil },
+	},
+}
+
+// schemaVersion returns the version recorded in a decoded state document,
+// files written before versioning existed count as version 0.
+func schemaVersion(doc map[string]any) int {
+	v, ok := doc["schema_version"].(float64)
+	if !ok {
+		return 0
+	}
+	return int(v)
+}
+
+// checkSchema refuses state files written by a newer zest, which this one
+// can neither read nor safely overwrite.
+func checkSchema(path string, version int) error {
+	if version > SchemaVersion {
+		return fmt.Errorf("%w: %v has schema version %d, this zest supports up to %d, please upgrade zest",
+			ErrSchemaTooNew, path, version, SchemaVersion)
+	}
+	return nil
+}
+
+// migrateJSON upgrades the state file contents in data to SchemaVersion.
+// The original file is kept at path.v<version>.bak before it is rewritten.
+// It returns the upgraded contents and whether anything changed.
+func migrateJSON(path string, data []byte, migrations []migration) ([]byte, bool, error) {
+	doc := map[string]any{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, false, err
+	}
+
+	version := schemaVersion(doc)
+	if err := checkSchema(path, version); err != nil {
+		return nil, false, err
+	}
+	if version == SchemaVersion {
+		return data, false, nil
+	}
+
+	original := fmt.Sprintf("%v.v%d.bak", path, version)
+	if err := utils.WriteFileAtomic(original, data, 0644); err != nil {
+		return nil, false, fmt.Errorf("failed to backup %v before migrating, %v", path, err)
+	}
+
+	for _, m := range migrations {
+		if m.From < version {
+			continue
+		}
+		if err := m.Apply(doc); err != nil {
+			return nil, false, fmt.Errorf("failed to migrate %v from schema version %d, %v", path, m.From, err)
+		}
+		version = m.From + 1
+	}
+	if version != SchemaVersion {
+		return nil, false, fmt.Errorf("no migration path for %v to schema version %d", path, SchemaVersion)
+	}
+	doc["schema_version"] = SchemaVersion
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
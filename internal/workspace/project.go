@@ -0,0 +1,112 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNoProjectFile utils.ZestErr = errors.New("no project file found")
+
+// FindProject returns the project file in dir or the closest of its parents.
+func FindProject(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for start := dir; ; {
+		path := filepath.Join(dir, launch.ProjectFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%w: no %s in %s or its parents", ErrNoProjectFile, launch.ProjectFile, start)
+		}
+		dir = parent
+	}
+}
+
+// invalidNameChars are replaced when a directory name becomes a workspace name.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// projectName returns the `name:` of the project file, or the name of the
+// directory holding it.
+func projectName(path string, data []byte) (string, error) {
+	spec := struct {
+		Name string `yaml:"name"`
+	}{}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return "", fmt.Errorf("failed to parse %s, %v", path, err)
+	}
+	if spec.Name != "" {
+		return spec.Name, nil
+	}
+
+	name := invalidNameChars.ReplaceAllString(filepath.Base(filepath.Dir(path)), "-")
+	return strings.TrimLeft(name, "_-"), nil
+}
+
+// RegisterProject adds the project file at path to the registry and returns
+// the name of its workspace. The workspace file only includes the project
+// file, which stays where it is. A file that is already registered keeps its
+// workspace.
+func RegisterProject(cfg *utils.ZestConfig, path string) (string, error) {
+	reg, err := NewWspRegistry(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to load registry, %v", err)
+	}
+	for _, wspCfg := range reg.Workspaces {
+		if wspCfg.Project == path {
+			return wspCfg.Name, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read project file, %v", err)
+	}
+	name, err := projectName(path, data)
+	if err != nil {
+		return "", err
+	}
+	if reg.Exists(name) {
+		return "", fmt.Errorf("%w: '%s', give the project another name in %s", ErrWorkspaceExists, name, path)
+	}
+
+	// every other key would override the project file
+	out, err := yaml.Marshal(struct {
+		Version int      `yaml:"version"`
+		Name    string   `yaml:"name"`
+		Include []string `yaml:"include"`
+	}{SpecVersion, name, []string{path}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal yaml file, %s", err)
+	}
+	out = append([]byte("# Registered from "+path+", edit that file instead.\n"), out...)
+
+	if err := InitWithSpec(cfg, name, "", out, false); err != nil {
+		return "", err
+	}
+
+	err = reg.Transact(func() error {
+		wspCfg, ok := reg.GetCfg(name)
+		if !ok {
+			return fmt.Errorf("%w: '%s'", ErrWorkspaceNotExists, name)
+		}
+		wspCfg.Project = path
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save workspace config, %v", err)
+	}
+	return name, nil
+}
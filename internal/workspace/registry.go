@@ -1,7 +1,6 @@
 package workspace
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -11,47 +10,109 @@ import (
 )
 
 // WspRegistry maintains a global map of all known workspaces.
-// This is stored in ~/.zest/state/workspace.json
+// This is stored in ~/.zest/state/workspaces.json
 type WspRegistry struct {
 	path string
 
+	SchemaVersion int `json:"schema_version"` // Version of the on-disk layout, see `SchemaVersion`
+
 	Workspaces map[string]*WspConfig `json:"workspaces"` // key = workspace name, value = path to its config YAML file
 }
 
 // NewWspRegistry loads the registry from disk or initializes an empty one.
 func NewWspRegistry(cfg *utils.ZestConfig) (*WspRegistry, error) {
-	regPath := filepath.Join(cfg.StateDir(), "workspaces.json")
-
 	reg := &WspRegistry{
-		path:       regPath,
+		path:       filepath.Join(cfg.StateDir(), "workspaces.json"),
 		Workspaces: map[string]*WspConfig{},
 	}
 
-	// Try to read from disk if exists
-	if data, err := os.ReadFile(regPath); err == nil {
-		if err := json.Unmarshal(data, &reg); err != nil {
-			return nil, err
-		}
+	lock, err := utils.LockFile(reg.lockPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock registry, %v", err)
+	}
+	defer lock.Unlock()
+
+	if err := reg.load(); err != nil {
+		return nil, err
 	}
 
 	return reg, nil
 }
 
+func (wr *WspRegistry) lockPath() string {
+	return wr.path + ".lock"
+}
+
+// load reads the registry from disk, the caller must hold the registry lock.
+func (wr *WspRegistry) load() error {
+	wr.Workspaces = map[string]*WspConfig{}
+	if err := loadJSON(wr.path, wr, registryMigrations); err != nil {
+		return err
+	}
+	return wr.migrateSpecs()
+}
+
+// save writes the registry to disk, the caller must hold the registry lock.
+func (wr *WspRegistry) save() error {
+	if wr.path == "" {
+		return errors.New("registry path is not set")
+	}
+	wr.SchemaVersion = SchemaVersion
+	return saveJSON(wr.path, wr)
+}
+
+// Transact locks the registry file, reloads the registry from disk, applies
+// fn and saves the result, so concurrent zest processes never lose updates.
+// Pointers obtained from the registry before the call are stale inside fn.
+func (wr *WspRegistry) Transact(fn func() error) error {
+	lock, err := utils.LockFile(wr.lockPath())
+	if err != nil {
+		return fmt.Errorf("failed to lock registry, %v", err)
+	}
+	defer lock.Unlock()
+
+	if err := wr.load(); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	return wr.save()
+}
+
+// migrateSpecs upgrades legacy workspace YAML files that still carry
+// zest-managed metadata, and saves the registry if any were rewritten.
+func (wr *WspRegistry) migrateSpecs() error {
+	migrated := false
+	for _, wspCfg := range wr.Workspaces {
+		ok, err := migrateSpec(wspCfg)
+		if err != nil {
+			return fmt.Errorf("failed to migrate workspace '%v', %v", wspCfg.Name, err)
+		}
+		migrated = migrated || ok
+	}
+
+	if !migrated {
+		return nil
+	}
+	return wr.save()
+}
+
 // Update adds or updates a workspace in the registry.
 func (wr *WspRegistry) Update(cfg *WspConfig) {
 	wr.Workspaces[cfg.Name] = cfg
 }
 
 // Save writes the current state of the registry to disk.
+// Prefer Transact when the write depends on what was read.
 func (wr *WspRegistry) Save() error {
-	if wr.path == "" {
-		return errors.New("registry path is not set")
-	}
-	data, err := json.MarshalIndent(wr, "", "  ")
+	lock, err := utils.LockFile(wr.lockPath())
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to lock registry, %v", err)
 	}
-	return os.WriteFile(wr.path, data, 0644)
+	defer lock.Unlock()
+
+	return wr.save()
 }
 
 // GetCfg return a pointer to `WspConfig` struct for the workspace name.
@@ -81,16 +142,23 @@ func (wr *WspRegistry) Exists(name string) bool {
 	return ok
 }
 
+// Delete removes the workspace config file and its registry entry.
+// A config file that is already gone is not an error.
 func (wr *WspRegistry) Delete(name string) error {
 	wspCfg, ok := wr.Workspaces[name]
 	if !ok {
 		return fmt.Errorf("failed to find workspace with %v ", name)
 	}
 
-	if err := os.Remove(wspCfg.Path); err != nil {
+	if err := os.Remove(wspCfg.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
 
 	delete(wr.Workspaces, name)
 	return nil
 }
+
+// Unregister removes the registry entry for a workspace, leaving its files untouched.
+func (wr *WspRegistry) Unregister(name string) {
+	delete(wr.Workspaces, name)
+}
@@ -1,10 +1,12 @@
 package workspace
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,6 +17,8 @@ import (
 var (
 	ErrWorkspaceIsActive   utils.ZestErr = errors.New("workspace already active")
 	ErrWorkspaceIsInactive utils.ZestErr = errors.New("workspace is inactive")
+	ErrAppNotRunning       utils.ZestErr = errors.New("app is not running")
+	ErrUntrackedApps       utils.ZestErr = errors.New("runtime does not track app ids, relaunch the workspace")
 )
 
 // WspRuntime captures the live state of a running workspace session.
@@ -22,18 +26,29 @@ var (
 type WspRuntime struct {
 	sync.Mutex
 
-	Name      string `json:"name"`       // Name of the workspace (duplicated for quick access)
-	RtFile    string `json:"-"`          // Runtime filepath
-	StartedAt string `json:"started_at"` // Timestamp when the workspace was launched (RFC3339 format)
-	AppCount  int    `json:"app_count"`  // Total number of applications launched during this session
+	SchemaVersion int `json:"schema_version"` // Version of the on-disk layout, see `SchemaVersion`
 
-	PIDs      [][]int  `json:"pids"`      // List of process IDs associated with the workspace, each process can have multiple pids associated with it
-	Processes []string `json:"processes"` // Commands or app names launched as part of this workspace
+	Name      string `json:"name"`              // Name of the workspace (duplicated for quick access)
+	Profile   string `json:"profile,omitempty"` // Profile the workspace was launched with, empty for the default
+	Group     string `json:"group,omitempty"`   // Group of the workspace when it was launched
+	RtFile    string `json:"-"`                 // Runtime filepath
+	StartedAt string `json:"started_at"`        // Timestamp when the workspace was launched (RFC3339 format)
+	AppCount  int    `json:"app_count"`         // Total number of applications launched during this session
+
+	PIDs      [][]int  `json:"pids"`              // List of process IDs associated with the workspace, each process can have multiple pids associated with it
+	Processes []string `json:"processes"`         // Commands or app names launched as part of this workspace
+	Apps      []string `json:"apps"`              // IDs of the apps that are running, aligned with PIDs
+	Stopped   []string `json:"stopped,omitempty"` // IDs of the apps stopped with `zest app stop`, `zest apply` leaves them stopped
+
+	Hashes    map[string]string `json:"hashes,omitempty"`     // Hash of the config each app was started with, keyed by app id
+	EnvHashes map[string]string `json:"env_hashes,omitempty"` // Hash of the environment each app was started with, keyed by app id
 
 	Ports       []int    `json:"ports,omitempty"`        // Ports opened by services within the workspace
 	BrowserURLs []string `json:"browser_urls,omitempty"` // Web URLs opened by this workspace (if any)
 
 	IsDetached bool `json:"is_detached"` // Indicates if the workspace was launched in detached/background mode
+
+	Launch launch.Options `json:"launch"` // Options the workspace was launched with, reused by `zest restart`
 }
 
 func NewWspRuntime(cfg *utils.ZestConfig, wspName string) (*WspRuntime, error) {
@@ -49,12 +64,44 @@ func (wspRt *WspRuntime) Load() error {
 	wspRt.Lock()
 	defer wspRt.Unlock()
 
-	if data, err := os.ReadFile(wspRt.RtFile); err == nil {
-		if err := json.Unmarshal(data, wspRt); err != nil {
-			return err
-		}
+	return loadJSON(wspRt.RtFile, wspRt, runtimeMigrations)
+}
+
+func (wspRt *WspRuntime) lockPath() string {
+	return wspRt.RtFile + ".lock"
+}
+
+// Transact locks the runtime file, reloads the runtime from disk, applies fn
+// and saves the result, so zest processes changing the apps of the same
+// workspace never lose each other's pids. The result is saved even when fn
+// fails, it may have started or stopped apps before it did. A runtime that
+// was deleted, e.g. by `zest close`, is not created again.
+func (wspRt *WspRuntime) Transact(fn func() error) error {
+	if wspRt.RtFile == "" {
+		return errors.New("runtime path is not set")
 	}
-	return nil
+
+	lock, err := utils.LockFile(wspRt.lockPath())
+	if err != nil {
+		return fmt.Errorf("failed to lock runtime, %v", err)
+	}
+	defer lock.Unlock()
+
+	if _, err := os.Stat(wspRt.RtFile); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: '%s'", ErrWorkspaceIsInactive, wspRt.Name)
+	}
+
+	// fields missing from the file must not survive from an earlier load
+	*wspRt = WspRuntime{Name: wspRt.Name, RtFile: wspRt.RtFile}
+	if err := wspRt.Load(); err != nil {
+		return err
+	}
+
+	fnErr := fn()
+	if err := wspRt.save(); err != nil {
+		return fmt.Errorf("failed to save runtime state, %v", err)
+	}
+	return fnErr
 }
 
 func (wspRt *WspRuntime) Monitor() error {
@@ -63,29 +110,190 @@ func (wspRt *WspRuntime) Monitor() error {
 
 func (wspRt *WspRuntime) Update(plan *launch.Plan) {
 	wspRt.StartedAt = time.Now().Format(time.RFC3339)
+	wspRt.Profile = plan.Profile
+	wspRt.Group = plan.Group
 	wspRt.AppCount = len(plan.Apps)
 	wspRt.PIDs = plan.GetPIDs()
 	wspRt.Processes = plan.GetProcessNames()
+	wspRt.Apps = plan.GetAppIDs()
+	wspRt.Hashes = plan.GetHashes()
+	wspRt.EnvHashes = map[string]string{}
+	for _, id := range wspRt.Apps {
+		wspRt.EnvHashes[id] = plan.EnvHash()
+	}
+}
+
+// Append records the apps of plan next to the ones already running.
+func (wspRt *WspRuntime) Append(plan *launch.Plan) {
+	wspRt.AppCount += len(plan.Apps)
+	wspRt.PIDs = append(wspRt.PIDs, plan.GetPIDs()...)
+	wspRt.Processes = append(wspRt.Processes, plan.GetProcessNames()...)
+	wspRt.Apps = append(wspRt.Apps, plan.GetAppIDs()...)
+	if wspRt.Hashes == nil {
+		wspRt.Hashes = map[string]string{}
+	}
+	maps.Copy(wspRt.Hashes, plan.GetHashes())
+	if wspRt.EnvHashes == nil {
+		wspRt.EnvHashes = map[string]string{}
+	}
+	for _, id := range plan.GetAppIDs() {
+		wspRt.EnvHashes[id] = plan.EnvHash()
+		wspRt.Stopped = slices.DeleteFunc(wspRt.Stopped, func(s string) bool { return s == id })
+	}
+}
+
+// FindApp returns the position of the app in PIDs and Processes, or -1.
+func (wspRt *WspRuntime) FindApp(id string) int {
+	for i, app := range wspRt.Apps {
+		if app == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetApp records the processes, config hash and environment hash of a
+// single app. An app that is already tracked keeps its position, any other
+// app is appended.
+func (wspRt *WspRuntime) SetApp(id, process, hash, envHash string, pids []int) error {
+	if len(wspRt.Apps) != len(wspRt.PIDs) {
+		return ErrUntrackedApps
+	}
+	if wspRt.Hashes == nil {
+		wspRt.Hashes = map[string]string{}
+	}
+	wspRt.Hashes[id] = hash
+	if wspRt.EnvHashes == nil {
+		wspRt.EnvHashes = map[string]string{}
+	}
+	wspRt.EnvHashes[id] = envHash
+	wspRt.Stopped = slices.DeleteFunc(wspRt.Stopped, func(s string) bool { return s == id })
+
+	if i := wspRt.FindApp(id); i >= 0 {
+		wspRt.PIDs[i] = pids
+		wspRt.Processes[i] = process
+	} else {
+		wspRt.Apps = append(wspRt.Apps, id)
+		wspRt.PIDs = append(wspRt.PIDs, pids)
+		wspRt.Processes = append(wspRt.Processes, process)
+	}
+	wspRt.AppCount = len(wspRt.Apps)
+	return nil
+}
+
+// RemoveApp stops tracking a single app and returns its pids.
+func (wspRt *WspRuntime) RemoveApp(id string) ([]int, error) {
+	if len(wspRt.Apps) != len(wspRt.PIDs) {
+		return nil, ErrUntrackedApps
+	}
+
+	i := wspRt.FindApp(id)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: '%s'", ErrAppNotRunning, id)
+	}
+	pids := wspRt.PIDs[i]
+
+	wspRt.Apps = append(wspRt.Apps[:i], wspRt.Apps[i+1:]...)
+	wspRt.PIDs = append(wspRt.PIDs[:i], wspRt.PIDs[i+1:]...)
+	if i < len(wspRt.Processes) {
+		wspRt.Processes = append(wspRt.Processes[:i], wspRt.Processes[i+1:]...)
+	}
+	wspRt.AppCount = len(wspRt.Apps)
+	delete(wspRt.Hashes, id)
+	delete(wspRt.EnvHashes, id)
+	return pids, nil
 }
 
+// PruneDead drops the pids that are no longer running and the apps left
+// without any, and returns how many pids are still running.
+func (wspRt *WspRuntime) PruneDead() int {
+	tracked := len(wspRt.Apps) == len(wspRt.PIDs)
+
+	alive := 0
+	for i := len(wspRt.PIDs) - 1; i >= 0; i-- {
+		kept := []int{}
+		for _, pid := range wspRt.PIDs[i] {
+			if utils.IsRunning(pid) {
+				kept = append(kept, pid)
+			}
+		}
+		alive += len(kept)
+		wspRt.PIDs[i] = kept
+		if len(kept) > 0 {
+			continue
+		}
+
+		if tracked {
+			wspRt.RemoveApp(wspRt.Apps[i])
+			continue
+		}
+		wspRt.PIDs = append(wspRt.PIDs[:i], wspRt.PIDs[i+1:]...)
+		if i < len(wspRt.Processes) {
+			wspRt.Processes = append(wspRt.Processes[:i], wspRt.Processes[i+1:]...)
+		}
+	}
+	return alive
+}
+
+// MarkStopped records that the user stopped an app, so it isn't started
+// again until it is asked for.
+func (wspRt *WspRuntime) MarkStopped(id string) {
+	if !slices.Contains(wspRt.Stopped, id) {
+		wspRt.Stopped = append(wspRt.Stopped, id)
+	}
+}
+
+// Save writes the runtime to disk, replacing what is there.
+// Prefer Transact when the write depends on what was read.
 func (wspRt *WspRuntime) Save() error {
 	if wspRt.RtFile == "" {
-		return errors.New("registry path is not set")
+		return errors.New("runtime path is not set")
 	}
-	data, err := json.MarshalIndent(wspRt, "", "  ")
+
+	lock, err := utils.LockFile(wspRt.lockPath())
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to lock runtime, %v", err)
 	}
+	defer lock.Unlock()
 
+	return wspRt.save()
+}
+
+func (wspRt *WspRuntime) save() error {
 	wspRt.Lock()
 	defer wspRt.Unlock()
 
-	return os.WriteFile(wspRt.RtFile, data, 0644)
+	wspRt.SchemaVersion = SchemaVersion
+	return saveJSON(wspRt.RtFile, wspRt)
 }
 
+// Delete removes the runtime file, its backups and its lock file. It holds
+// the lock while it does, so a Transact waiting on it finds no runtime
+// instead of writing it back.
 func (wspRt *WspRuntime) Delete() error {
+	if wspRt.RtFile == "" {
+		return errors.New("runtime path is not set")
+	}
+
+	lock, err := utils.LockFile(wspRt.lockPath())
+	if err != nil {
+		return fmt.Errorf("failed to lock runtime, %v", err)
+	}
+	defer lock.Unlock()
+
 	wspRt.Lock()
 	defer wspRt.Unlock()
 
-	return os.Remove(wspRt.RtFile)
+	backups, _ := filepath.Glob(wspRt.RtFile + ".*bak")
+	for _, bak := range backups {
+		if err := os.Remove(bak); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Remove(wspRt.RtFile); err != nil {
+		return err
+	}
+	// best effort, Windows doesn't remove a file that is open
+	os.Remove(wspRt.lockPath())
+	return nil
 }
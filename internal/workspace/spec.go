@@ -0,0 +1,127 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecVersion is the current version of the user-editable workspace spec.
+const SpecVersion = 1
+
+// legacyMetaKeys are zest-managed fields that older versions wrote into the
+// workspace YAML. They now live only in the registry.
+var legacyMetaKeys = []string{"status", "created", "last_updated", "last_used", "path", "template"}
+
+// WspSpec defines the user-editable specification of a workspace.
+// This is stored as a YAML file at ~/.zest/workspaces/<name>.yaml
+type WspSpec struct {
+	Version      int    `yaml:"version"`         // Version of the spec format
+	Name         string `yaml:"name"`            // Name of the workspace
+	WorkspaceDir string `yaml:"workspace_dir"`   // Root directory where all commands will be executed
+	Group        string `yaml:"group,omitempty"` // Group closed together by `zest switch --close-group`
+
+	Extends string            `yaml:"extends,omitempty"` // Workspace or template this one is based on
+	Include []string          `yaml:"include,omitempty"` // Fragment files merged in before this spec
+	Vars    map[string]string `yaml:"vars,omitempty"`    // Variables for a template named in extends
+
+	Env      map[string]string           `yaml:"env,omitempty"`       // Environment variables applied to every app
+	EnvFiles []string                    `yaml:"env_files,omitempty"` // Dotenv files layered on top of env
+	Hooks    map[string]any              `yaml:"hooks,omitempty"`     // Commands run around launch and close, see `launch.Hooks`
+	Git      map[string]any              `yaml:"git,omitempty"`       // Branch to check out before launch, see `launch.Git`
+	Tasks    map[string]map[string]any   `yaml:"tasks,omitempty"`     // One-off commands run with `zest run`
+	Apps     map[string][]map[string]any `yaml:"apps"`                // Apps to launch, keyed by app type
+	Profiles map[string]map[string]any   `yaml:"profiles,omitempty"`  // Named variants that enable, disable or override apps
+}
+
+// NewWspSpec returns an empty spec for the workspace name.
+func NewWspSpec(name string) *WspSpec {
+	return &WspSpec{
+		Version: SpecVersion,
+		Name:    name,
+		Apps:    map[string][]map[string]any{},
+	}
+}
+
+// Save writes the spec as YAML to the given path.
+func (spec *WspSpec) Save(path string) error {
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal yaml file, %s", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// migrateSpec strips zest-managed metadata from a legacy workspace YAML and
+// stamps it with the current spec version. The stripped values are copied
+// into wspCfg wherever the registry has none, and the original file is kept
+// as <path>.v0.bak. It reports whether the file was rewritten.
+func migrateSpec(wspCfg *WspConfig) (bool, error) {
+	data, err := os.ReadFile(wspCfg.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("failed to parse %v, %v", wspCfg.Path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return false, nil
+	}
+	root := doc.Content[0]
+
+	if v := utils.MappingValue(root, "version"); v != nil {
+		return false, nil
+	}
+
+	meta := map[string]string{}
+	for _, key := range legacyMetaKeys {
+		if v := utils.RemoveMappingKey(root, key); v != nil {
+			meta[key] = v.Value
+		}
+	}
+
+	if wspCfg.Status == "" && meta["status"] != "" {
+		wspCfg.Status = Status(meta["status"])
+	}
+	if wspCfg.Template == "" {
+		wspCfg.Template = meta["template"]
+	}
+	if wspCfg.Created == "" {
+		wspCfg.Created = meta["created"]
+	}
+	if wspCfg.LastUpdated == "" {
+		wspCfg.LastUpdated = meta["last_updated"]
+	}
+	if wspCfg.LastUsed == "" {
+		wspCfg.LastUsed = meta["last_used"]
+	}
+
+	version := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"},
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: fmt.Sprint(SpecVersion)},
+	}
+	root.Content = append(version, root.Content...)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return false, err
+	}
+
+	// unversioned specs are version 0, keep them next to the file like migrateJSON does
+	original := fmt.Sprintf("%v.v0.bak", wspCfg.Path)
+	if err := utils.WriteFileAtomic(original, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to backup %v before migrating, %v", wspCfg.Path, err)
+	}
+	if err := utils.WriteFileAtomic(wspCfg.Path, out, 0644); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,75 @@
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AVAniketh0905/zest/internal/utils"
+)
+
+// backupPath returns the path of the last known good copy of a state file.
+func backupPath(path string) string {
+	return path + ".bak"
+}
+
+// saveJSON atomically writes v to path as indented JSON. The previous
+// contents are kept as a backup if they were valid JSON. A file written by
+// a newer zest in the meantime is left alone.
+func saveJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if old, err := os.ReadFile(path); err == nil && json.Valid(old) {
+		doc := map[string]any{}
+		if json.Unmarshal(old, &doc) == nil {
+			if err := checkSchema(path, schemaVersion(doc)); err != nil {
+				return err
+			}
+		}
+		if err := utils.WriteFileAtomic(backupPath(path), old, 0644); err != nil {
+			return fmt.Errorf("failed to backup %v, %v", path, err)
+		}
+	}
+
+	return utils.WriteFileAtomic(path, data, 0644)
+}
+
+// loadJSON reads the JSON state file at path into v, upgrading it with
+// migrations first. A missing file leaves v untouched. A corrupt file is
+// restored from its backup when possible.
+func loadJSON(path string, v any, migrations []migration) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	restored := false
+	if !json.Valid(data) {
+		bak, err := os.ReadFile(backupPath(path))
+		if err != nil || !json.Valid(bak) {
+			return fmt.Errorf("state file %v is corrupt and no valid backup was found", path)
+		}
+		fmt.Fprintf(os.Stderr, "[zest] warning: %v is corrupt, restored from backup\n", path)
+		data, restored = bak, true
+	}
+
+	data, migrated, err := migrateJSON(path, data, migrations)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if restored || migrated {
+		return utils.WriteFileAtomic(path, data, 0644)
+	}
+	return nil
+}
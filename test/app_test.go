@@ -0,0 +1,157 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+// setRuntimeApps overwrites the tracked apps and profile of an active workspace.
+func setRuntimeApps(t *testing.T, cfg *utils.ZestConfig, name, profile string, apps []string, pids [][]int) {
+	rtFile := filepath.Join(cfg.RuntimeWspDir(), name+".json")
+	data, err := os.ReadFile(rtFile)
+	require.NoError(t, err)
+
+	rt := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &rt))
+	rt["profile"] = profile
+	rt["apps"] = apps
+	rt["pids"] = pids
+	processes := []string{}
+	for range apps {
+		processes = append(processes, "proc")
+	}
+	rt["processes"] = processes
+	if apps == nil {
+		delete(rt, "apps")
+	}
+
+	data, err = json.Marshal(rt)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(rtFile, data, 0644))
+}
+
+func loadRuntime(t *testing.T, cfg *utils.ZestConfig, name string) *workspace.WspRuntime {
+	rt, err := workspace.NewWspRuntime(cfg, name)
+	require.NoError(t, err)
+	require.NoError(t, rt.Load())
+	return rt
+}
+
+func TestAppCommand_RequiresActiveWorkspace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "list", "web"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "server")
+	require.Contains(t, string(out), "stopped")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "start", "web", "server"})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceIsInactive)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "stop", "web", "server"})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceIsInactive)
+}
+
+func TestAppCommand_StopUpdatesRuntimeInPlace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	// an active workspace with nothing running yet
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+
+	setRuntimeApps(t, cfg, "web", "full", []string{"vscode", "server", "db"}, [][]int{{}, {os.Getpid()}, {}})
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "list", "web"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "running")
+
+	// stopping one app keeps the others as they are
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "stop", "web", "vscode"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "App 'vscode' stopped.")
+
+	rt := loadRuntime(t, cfg, "web")
+	require.Equal(t, []string{"server", "db"}, rt.Apps)
+	require.Equal(t, [][]int{{os.Getpid()}, {}}, rt.PIDs)
+	require.Len(t, rt.Processes, 2)
+	require.Equal(t, 2, rt.AppCount)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "stop", "web", "vscode"})
+	require.ErrorIs(t, err, workspace.ErrAppNotRunning)
+
+	// a live app can't be started twice
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "start", "web", "server"})
+	require.ErrorContains(t, err, "already running")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "start", "web", "chat"})
+	require.ErrorIs(t, err, launch.ErrNoMatchingApp)
+	require.ErrorContains(t, err, "available: browser, server, db, vscode")
+}
+
+func TestAppCommand_RejectsUntrackedRuntime(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+
+	// runtimes written before app ids were tracked
+	setRuntimeApps(t, cfg, "web", "full", nil, [][]int{{}, {}})
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "stop", "web", "vscode"})
+	require.ErrorIs(t, err, workspace.ErrUntrackedApps)
+}
+
+func TestAppCommand_StoppedAppStaysDownOnApply(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the apps below run sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	spec := `version: 1
+apps:
+  custom:
+    - {id: a, name: sleep, cmd: sleep, args: ["35"]}
+    - {id: b, name: sleep, cmd: sleep, args: ["36"]}
+`
+	writeSpec(t, cfg, tempDir, "work", spec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.NoError(t, err)
+	t.Cleanup(func() { setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "work"}) })
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "stop", "work", "a"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, loadRuntime(t, cfg, "work").Stopped)
+
+	// an edit to another app doesn't bring the stopped one back
+	editSpec(t, cfg, "work", strings.Replace(spec, `"36"`, `"37"`, 1))
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"apply", "work"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "0 added, 0 removed, 1 changed")
+	require.Equal(t, []string{"b"}, loadRuntime(t, cfg, "work").Apps)
+
+	// until it is started again
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "start", "work", "a"})
+	require.NoError(t, err)
+	rt := loadRuntime(t, cfg, "work")
+	require.ElementsMatch(t, []string{"a", "b"}, rt.Apps)
+	require.Empty(t, rt.Stopped)
+}
@@ -0,0 +1,248 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+// setRuntimeField overwrites a single key of the runtime of an active workspace.
+func setRuntimeField(t *testing.T, cfg *utils.ZestConfig, name, key string, value any) {
+	rtFile := filepath.Join(cfg.RuntimeWspDir(), name+".json")
+	data, err := os.ReadFile(rtFile)
+	require.NoError(t, err)
+
+	rt := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &rt))
+	rt[key] = value
+
+	data, err = json.Marshal(rt)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(rtFile, data, 0644))
+}
+
+// editSpec rewrites the YAML of a workspace without touching its state.
+func editSpec(t *testing.T, cfg *utils.ZestConfig, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.WspDir(), name+".yaml"), []byte(content), 0644))
+}
+
+func TestApply_HashFollowsStartConfig(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	plan, err := launch.NewLaunchPlan(cfg, "web", "")
+	require.NoError(t, err)
+	hashes := plan.GetHashes()
+	require.Len(t, hashes, 4)
+	require.NotEqual(t, hashes["server"], hashes["db"])
+
+	// tags and ids don't change how an app starts
+	writeSpec(t, cfg, tempDir, "web", strings.Replace(subsetSpec, "tags: [backend]", "tags: [db]", 1))
+	plan, err = launch.NewLaunchPlan(cfg, "web", "")
+	require.NoError(t, err)
+	require.Equal(t, hashes, plan.GetHashes())
+
+	// nor does the environment, it is hashed on its own
+	envHash := plan.EnvHash()
+	plan.ApplyEnv(map[string]string{"MODE": "dev"})
+	require.Equal(t, hashes, plan.GetHashes())
+	require.NotEqual(t, envHash, plan.EnvHash())
+}
+
+func TestApply_DiffKeepsUnchangedApps(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	spec := strings.Replace(subsetSpec, `  browser:
+    - tabs: ["http://localhost:3000"]
+`, `  browser:
+    - tabs: ["http://localhost:3000"]
+    - tabs: ["http://localhost:3000/admin"]
+`, 1)
+	writeSpec(t, cfg, tempDir, "web", spec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+
+	plan, err := launch.NewLaunchPlan(cfg, "web", "full")
+	require.NoError(t, err)
+	ids := plan.GetAppIDs()
+	pids := [][]int{}
+	for range ids {
+		pids = append(pids, []int{})
+	}
+	setRuntimeApps(t, cfg, "web", "full", ids, pids)
+	setRuntimeField(t, cfg, "web", "hashes", plan.GetHashes())
+	envHashes := map[string]string{}
+	for _, id := range ids {
+		envHashes[id] = plan.EnvHash()
+	}
+	setRuntimeField(t, cfg, "web", "env_hashes", envHashes)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "web"})
+	require.NoError(t, err)
+	require.Equal(t, "No changes.\n", string(out))
+
+	// a new browser entry in front of the others is the only change
+	editSpec(t, cfg, "web", strings.Replace(spec, `  browser:
+`, `  browser:
+    - tabs: ["http://localhost:6006"]
+`, 1))
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "web", "--json"})
+	require.NoError(t, err)
+	var diff workspace.AppDiff
+	require.NoError(t, json.Unmarshal(out, &diff))
+	require.Len(t, diff.Added, 1)
+	require.NotContains(t, ids, diff.Added[0])
+	require.Empty(t, diff.Removed)
+	require.Empty(t, diff.Changed)
+	require.Empty(t, diff.Env)
+
+	// a changed env restarts every running app, but changes no config
+	editSpec(t, cfg, "web", spec+"env:\n  MODE: dev\n")
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "web", "--json"})
+	require.NoError(t, err)
+	diff = workspace.AppDiff{}
+	require.NoError(t, json.Unmarshal(out, &diff))
+	require.Empty(t, diff.Changed)
+	require.ElementsMatch(t, ids, diff.Env)
+}
+
+func TestApply_RestartsAppsWithChangedEnv(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("reads the environment of the app from /proc")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	spec := `version: 1
+apps:
+  custom:
+    - {id: sleeper, name: sleep, cmd: sleep, args: ["34"]}
+`
+	writeSpec(t, cfg, tempDir, "work", spec+"env:\n  MODE: test\n")
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.NoError(t, err)
+	t.Cleanup(func() { setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "work"}) })
+	before := loadRuntime(t, cfg, "work").PIDs[0]
+	require.Contains(t, procEnv(t, before[0]), "MODE=test")
+
+	editSpec(t, cfg, "work", spec+"env:\n  MODE: dev\n")
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "work"})
+	require.NoError(t, err)
+	require.Equal(t, "~ sleeper (env changed)\n", string(out))
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"apply", "work"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "0 added, 0 removed, 1 changed")
+
+	after := loadRuntime(t, cfg, "work").PIDs[0]
+	require.NotEqual(t, before, after)
+	require.Contains(t, procEnv(t, after[0]), "MODE=dev")
+
+	// the new env is recorded, so there is nothing left to apply
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "work"})
+	require.NoError(t, err)
+	require.Equal(t, "No changes.\n", string(out))
+}
+
+// procEnv returns the environment a process was started with.
+func procEnv(t *testing.T, pid int) []string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
+	require.NoError(t, err)
+	return strings.Split(strings.TrimRight(string(data), "\x00"), "\x00")
+}
+
+func TestApply_DiffAgainstRuntime(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "web"})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceIsInactive)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+
+	plan, err := launch.NewLaunchPlan(cfg, "web", "full")
+	require.NoError(t, err)
+	hashes := plan.GetHashes()
+	setRuntimeApps(t, cfg, "web", "full", []string{"vscode", "server", "db"}, [][]int{{}, {}, {}})
+	setRuntimeField(t, cfg, "web", "hashes", map[string]string{"vscode": hashes["vscode"], "server": hashes["server"]})
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "web"})
+	require.NoError(t, err)
+	require.Equal(t, "+ browser\n~ db\n", string(out))
+
+	// drop the db and change the server command
+	spec := strings.Replace(subsetSpec, `    - id: db
+      tabs: ["docker compose up db"]
+      tags: [backend]
+`, "", 1)
+	spec = strings.Replace(spec, "npm run dev", "npm start", 1)
+	editSpec(t, cfg, "web", spec)
+	setRuntimeApps(t, cfg, "web", "full", []string{"vscode", "server", "db"}, [][]int{{}, {}, {}})
+	setRuntimeField(t, cfg, "web", "hashes", hashes)
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"diff", "web", "--json"})
+	require.NoError(t, err)
+	var diff workspace.AppDiff
+	require.NoError(t, json.Unmarshal(out, &diff))
+	require.Equal(t, workspace.AppDiff{
+		Added:   []string{"browser"},
+		Removed: []string{"db"},
+		Changed: []string{"server"},
+		Env:     []string{},
+	}, diff)
+
+	// a dry run leaves the runtime alone
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"apply", "web", "--dry-run"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"vscode", "server", "db"}, loadRuntime(t, cfg, "web").Apps)
+}
+
+func TestApply_StopsRemovedApps(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+
+	plan, err := launch.NewLaunchPlan(cfg, "web", "full")
+	require.NoError(t, err)
+	setRuntimeApps(t, cfg, "web", "full", []string{"vscode", "db"}, [][]int{{}, {}})
+	setRuntimeField(t, cfg, "web", "hashes", plan.GetHashes())
+	setRuntimeField(t, cfg, "web", "launch", map[string]any{"filter": map[string]any{"only": []string{"vscode"}}})
+
+	editSpec(t, cfg, "web", strings.Replace(subsetSpec, `    - id: db
+      tabs: ["docker compose up db"]
+      tags: [backend]
+`, "", 1))
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"apply", "web"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "- db")
+	require.Contains(t, string(out), "Workspace 'web' applied: 0 added, 1 removed, 0 changed.")
+
+	rt := loadRuntime(t, cfg, "web")
+	require.Equal(t, []string{"vscode"}, rt.Apps)
+	require.NotContains(t, rt.Hashes, "db")
+	require.Equal(t, plan.GetHashes()["vscode"], rt.Hashes["vscode"])
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"apply", "web"})
+	require.NoError(t, err)
+	require.Equal(t, "No changes.\n", string(out))
+}
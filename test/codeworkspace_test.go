@@ -0,0 +1,116 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+const codeWorkspaceFile = `{
+	// the services of the platform
+	"folders": [
+		{ "path": "api", "name": "backend" },
+		{ "path": "../web" },
+		{ "uri": "vscode-remote://ssh-remote+box/srv/docs" }, // not on this machine
+	],
+	/* shared by every folder */
+	"settings": {
+		"files.exclude": { "**/node_modules": true },
+		"http.proxy": "http://proxy:8080",
+	},
+	"extensions": { "recommendations": ["golang.go",] },
+}
+`
+
+func TestParseCodeWorkspace_AllowsCommentsAndTrailingCommas(t *testing.T) {
+	ws, err := launch.ParseCodeWorkspace([]byte(codeWorkspaceFile))
+	require.NoError(t, err)
+
+	require.Len(t, ws.Folders, 3)
+	require.Equal(t, launch.CodeFolder{Path: "api", Name: "backend"}, ws.Folders[0])
+	require.Equal(t, "vscode-remote://ssh-remote+box/srv/docs", ws.Folders[2].URI)
+	require.Equal(t, "http://proxy:8080", ws.Settings["http.proxy"])
+	require.Equal(t, []string{"golang.go"}, ws.Extensions.Recommendations)
+
+	_, err = launch.ParseCodeWorkspace([]byte(`{"folders": [`))
+	require.Error(t, err)
+}
+
+func TestVSCode_FoldersOpenGeneratedCodeWorkspace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	root := filepath.Join(tempDir, "src")
+
+	writeSpec(t, cfg, tempDir, "platform", "version: 1\nworkspace_dir: "+filepath.ToSlash(root)+`
+apps:
+  vscode:
+    - path: docs
+      folders:
+        - api
+        - path: web
+          name: frontend
+      settings:
+        editor.formatOnSave: true
+      extensions: [golang.go]
+`)
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "platform", "--dry-run"})
+	require.NoError(t, err)
+
+	file := filepath.Join(cfg.CodeWorkspaceDir(), "platform-vscode.code-workspace")
+	require.Contains(t, string(out), "Opening workspace: "+file)
+	require.Contains(t, string(out), "Folders: ["+utils.JoinQuoted([]string{
+		filepath.Join(root, "docs"), filepath.Join(root, "api"), filepath.Join(root, "web"),
+	})+"]")
+	require.Contains(t, string(out), "Extensions: [\"golang.go\"]")
+	require.NoFileExists(t, file, "a dry run writes nothing")
+
+	// a plain vscode app still opens its folder
+	writeSpec(t, cfg, tempDir, "plain", "version: 1\napps:\n  vscode:\n    - path: "+filepath.ToSlash(root)+"\n")
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "plain", "--dry-run"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Opening folder: "+filepath.ToSlash(root))
+}
+
+func TestImport_CreatesWorkspaceFromCodeWorkspace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	dir := filepath.Join(tempDir, "src", "platform")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	file := filepath.Join(dir, "Platform Team.code-workspace")
+	require.NoError(t, os.WriteFile(file, []byte(codeWorkspaceFile), 0644))
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"import", file})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Workspace 'Platform-Team' imported")
+
+	plan, err := launch.NewLaunchPlan(cfg, "Platform-Team", "")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "api"), plan.WorkingDir)
+	require.Len(t, plan.Apps, 1)
+	vscode := plan.Apps[0].(*launch.VSCodeApp)
+	require.Equal(t, []launch.CodeFolder{
+		{Path: filepath.Join(dir, "api"), Name: "backend"},
+		{Path: filepath.Join(tempDir, "src", "web")},
+	}, vscode.Folders)
+	require.Equal(t, "http://proxy:8080", vscode.Settings["http.proxy"])
+	require.Equal(t, []string{"golang.go"}, vscode.Extensions)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"import", file})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceExists)
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"import", file, "--name", "platform", "--force"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Workspace 'platform' imported")
+
+	// a file without local folders has nothing to open
+	remote := filepath.Join(dir, "remote.code-workspace")
+	require.NoError(t, os.WriteFile(remote, []byte(`{"folders": [{"uri": "vscode-remote://box/srv"}]}`), 0644))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"import", remote})
+	require.ErrorContains(t, err, "no folders on this machine")
+}
@@ -0,0 +1,301 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGlobalConfig(t *testing.T, cfg *utils.ZestConfig, content string) {
+	require.NoError(t, os.MkdirAll(cfg.RootDir(), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.RootDir(), "zest.yaml"), []byte(content), 0644))
+}
+
+func TestGlobalConfig_DefaultsApplyWithoutFile(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+
+	require.Empty(t, cfg.ConfigFile)
+	require.Equal(t, utils.DefaultSettings(), cfg.Settings)
+}
+
+func TestGlobalConfig_ListDefaultsFromFile(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, `
+list:
+  filter: active
+`)
+
+	rootCmd := cmd.NewRootCmd(cfg)
+	for _, name := range []string{"running", "idle"} {
+		_, err := setupAndRun(rootCmd, tempDir, []string{"init", name})
+		require.NoError(t, err)
+	}
+	_, err := setupAndRun(rootCmd, tempDir, []string{"launch", "running"})
+	require.NoError(t, err)
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "running")
+	require.NotContains(t, string(output), "idle")
+
+	// Flags still win over the config file
+	output, err = setupAndRun(rootCmd, tempDir, []string{"list", "--filter", "all"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "idle")
+}
+
+func TestGlobalConfig_EnvOverridesFile(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, `
+output: table
+launch:
+  parallelism: 2
+`)
+	t.Setenv("ZEST_OUTPUT", "json")
+
+	rootCmd := cmd.NewRootCmd(cfg)
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "dev"})
+	require.NoError(t, err)
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+
+	rows, err := parseWorkspaceListJSONOutput(output)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, 2, cfg.Settings.Launch.Parallelism)
+}
+
+func TestGlobalConfig_OnlyOwningCommandFlagsOverride(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "launch:\n  parallelism: 2\n")
+	writeSpec(t, cfg, tempDir, "dev", "version: 1\napps: {}\n")
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "dev", "--dry-run", "--parallel", "3"})
+	require.NoError(t, err)
+	require.Equal(t, 3, cfg.Settings.Launch.Parallelism)
+
+	// a flag of the same name on another command means something else
+	rootCmd := cmd.NewRootCmd(cfg)
+	probe := &cobra.Command{Use: "probe", RunE: func(*cobra.Command, []string) error { return nil }}
+	probe.Flags().Int("parallel", 0, "")
+	rootCmd.AddCommand(probe)
+	_, err = setupAndRun(rootCmd, tempDir, []string{"probe", "--parallel", "7"})
+	require.NoError(t, err)
+	require.Equal(t, 2, cfg.Settings.Launch.Parallelism)
+}
+
+func TestGlobalConfig_EnvAndBackendsReachLaunchPlan(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, `
+terminal: custom
+env:
+  GLOBAL: "yes"
+  MODE: global
+`)
+
+	rootCmd := cmd.NewRootCmd(cfg)
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "dev"})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.WspDir(), "dev.yaml"), []byte(`
+version: 1
+name: dev
+env:
+  MODE: workspace
+apps:
+  terminal:
+    - name: echoapp
+      cmd: echo
+`), 0644))
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"launch", "dev", "--dry-run"})
+	require.NoError(t, err)
+
+	out := string(output)
+	require.Contains(t, out, "[custom] echoapp")
+	require.Contains(t, out, "GLOBAL=yes")
+	require.Contains(t, out, "MODE=workspace")
+}
+
+func TestGlobalConfig_RejectsInvalidValues(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, `
+output: xml
+`)
+
+	rootCmd := cmd.NewRootCmd(cfg)
+	_, err := setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "output must be table or json")
+}
+
+func TestConfigCommand_SetGetUnset(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"config", "set", "launch.parallelism", "4"})
+	require.NoError(t, err)
+	_, err = setupAndRun(rootCmd, tempDir, []string{"config", "set", "env.MY_VAR", "123"})
+	require.NoError(t, err)
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"config", "get", "launch.parallelism"})
+	require.NoError(t, err)
+	require.Equal(t, "4\n", string(output))
+
+	output, err = setupAndRun(rootCmd, tempDir, []string{"config", "get", "env.MY_VAR"})
+	require.NoError(t, err)
+	require.Equal(t, "123\n", string(output))
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"config", "unset", "launch.parallelism"})
+	require.NoError(t, err)
+
+	output, err = setupAndRun(rootCmd, tempDir, []string{"config", "get", "launch.parallelism"})
+	require.NoError(t, err)
+	require.Equal(t, "1\n", string(output))
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"config", "unset", "launch.parallelism"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not set")
+}
+
+func TestConfigCommand_SetPreservesComments(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, `# my zest settings
+close:
+  grace_period: 5s # give editors time to save
+output: table
+`)
+
+	rootCmd := cmd.NewRootCmd(cfg)
+	_, err := setupAndRun(rootCmd, tempDir, []string{"config", "set", "close.grace_period", "10s"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(cfg.RootDir(), "zest.yaml"))
+	require.NoError(t, err)
+
+	content := string(data)
+	require.Contains(t, content, "# my zest settings")
+	require.Contains(t, content, "grace_period: 10s # give editors time to save")
+	require.Contains(t, content, "output: table")
+}
+
+func TestConfigCommand_RejectsInvalidKeysAndValues(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"config", "set", "no.such.key", "1"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown config key")
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"config", "set", "launch.parallelism", "many"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected a number")
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"config", "set", "output", "xml"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "output must be table or json")
+
+	// Nothing was written
+	_, err = os.Stat(filepath.Join(cfg.RootDir(), "zest.yaml"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestConfigCommand_ListShowsOrigin(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, `
+list:
+  sort: status
+`)
+	t.Setenv("ZEST_OUTPUT", "json")
+
+	rootCmd := cmd.NewRootCmd(cfg)
+	output, err := setupAndRun(rootCmd, tempDir, []string{"config", "list", "--show-origin"})
+	require.NoError(t, err)
+
+	lines := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		require.Len(t, fields, 2)
+		lines[strings.SplitN(fields[1], "=", 2)[0]] = fields[0]
+	}
+
+	require.Equal(t, "file:"+filepath.Join(cfg.RootDir(), "zest.yaml"), lines["list.sort"])
+	require.Equal(t, "env:ZEST_OUTPUT", lines["output"])
+	require.Equal(t, "default", lines["launch.parallelism"])
+
+	// the global --output flag wins over the environment
+	output, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"config", "list", "--show-origin", "--output", "table"})
+	require.NoError(t, err)
+	require.Regexp(t, `(?m)^flag:--output\s+output=table$`, string(output))
+}
+
+func TestConfigCommand_OutputFlagAppliesToEveryCommand(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", "work"})
+	require.NoError(t, err)
+
+	output, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"list", "--output", "json"})
+	require.NoError(t, err)
+	require.True(t, json.Valid(output), string(output))
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"list", "--output", "xml"})
+	require.ErrorContains(t, err, "output must be table or json")
+}
+
+func TestConfigCommand_PathAndBrokenConfig(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "output: xml\n")
+
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	// Other commands refuse to run with a broken config...
+	_, err := setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.Error(t, err)
+
+	// ...but it can still be repaired
+	output, err := setupAndRun(rootCmd, tempDir, []string{"config", "path"})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(cfg.RootDir(), "zest.yaml")+"\n", string(output))
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"config", "unset", "output"})
+	require.NoError(t, err)
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+}
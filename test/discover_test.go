@@ -0,0 +1,141 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTree creates files below root, keyed by their slash separated path.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestDiscover_ListsThenCreates(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	src := filepath.Join(tempDir, "src")
+	writeTree(t, src, map[string]string{
+		"github/api/go.mod":                      "module api\n",
+		"github/api/.git/config":                 "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = git@github.com:acme/api.git\n\tfetch = +refs/heads/*:refs/remotes/origin/*\n",
+		"github/api/.git/worktrees/wt/commondir": "../..\n",
+		"github/web/package.json":                `{"scripts": {"dev": "vite"}}`,
+		"github/web/.git/config":                 "[remote \"upstream\"]\n\turl = ssh://git@gitlab.com:2222/acme/web.git\n",
+		"gitlab/api/pyproject.toml":              "",
+		"gitlab/api/sub/go.mod":                  "module sub\n",
+		"wt/.git":                                "gitdir: ../github/api/.git/worktrees/wt\n",
+		"lib/go.mod":                             "module lib\n",
+		"lib/.zest.yaml":                         "version: 1\n",
+		"notes/notes.code-workspace":             "{}",
+		"node_modules/x/package.json":            "{}",
+		"a/b/c/deep/go.mod":                      "module deep\n",
+	})
+	writeSpec(t, cfg, tempDir, "notes", dirSpec(filepath.Join(src, "notes"), ""))
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"discover", src})
+	require.NoError(t, err)
+	if launch.Supported(utils.DefaultSettings().Terminal) {
+		require.Contains(t, string(out), "create: browser, editor, terminal")
+	} else {
+		require.Contains(t, string(out), "create: browser, editor")
+		require.NotContains(t, string(out), "terminal")
+	}
+	require.Contains(t, string(out), "gitlab-api")
+	require.Contains(t, string(out), "skip: already workspace 'notes'")
+	require.Contains(t, string(out), "skip: has a .zest.yaml")
+	require.NotContains(t, string(out), "deep")
+	require.NotContains(t, string(out), "node_modules")
+	require.NotContains(t, string(out), "sub")
+	require.Contains(t, string(out), "Run again with --yes to create 4 workspace(s).")
+
+	// nothing is created without --yes
+	reg, err := workspace.NewWspRegistry(cfg)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"notes"}, reg.GetNames())
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"discover", src, "--yes"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Created 4 workspace(s).")
+
+	reg, err = workspace.NewWspRegistry(cfg)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"notes", "api", "web", "gitlab-api", "wt"}, reg.GetNames())
+
+	plan, err := launch.NewLaunchPlan(cfg, "api", "")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(src, "github", "api"), plan.WorkingDir)
+	require.NoError(t, plan.CheckSupported())
+	summary := plan.Summary()
+	require.Contains(t, summary, "https://github.com/acme/api")
+	if runtime.GOOS == "windows" {
+		require.Contains(t, summary, "go build ./...")
+	}
+
+	plan, err = launch.NewLaunchPlan(cfg, "web", "")
+	require.NoError(t, err)
+	require.Contains(t, plan.Summary(), "https://gitlab.com/acme/web")
+	if runtime.GOOS == "windows" {
+		require.Contains(t, plan.Summary(), "npm run dev")
+	}
+
+	// a worktree shares the remote of its repository
+	plan, err = launch.NewLaunchPlan(cfg, "wt", "")
+	require.NoError(t, err)
+	require.Contains(t, plan.Summary(), "https://github.com/acme/api")
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"discover", src, "--depth", "1"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Nothing to create.")
+}
+
+func TestDiscover_SkipsUnreadableDirs(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	src := filepath.Join(tempDir, "src")
+	writeTree(t, src, map[string]string{
+		"api/go.mod":            "module api\n",
+		"private/secret/go.mod": "module secret\n",
+	})
+	private := filepath.Join(src, "private")
+	require.NoError(t, os.Chmod(private, 0))
+	t.Cleanup(func() { os.Chmod(private, 0755) })
+	if _, err := os.ReadDir(private); err == nil {
+		t.Skip("directory permissions are not enforced for this user")
+	}
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"discover", src})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "api")
+	require.NotContains(t, string(out), "secret")
+	require.Contains(t, string(out), "Run again with --yes to create 1 workspace(s).")
+}
+
+func TestDiscover_UsesConfiguredEditor(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "editor: zed\n")
+	src := filepath.Join(tempDir, "src")
+	writeTree(t, src, map[string]string{
+		"notes/notes.code-workspace": "{}",
+	})
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"discover", src, "--yes"})
+	require.NoError(t, err)
+
+	// zed opens the project root, the .code-workspace file is for VS Code
+	root := filepath.Join(src, "notes")
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "notes", "--dry-run", "--only", "editor"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Command: ["+utils.JoinQuoted([]string{"zed", root})+"]")
+}
@@ -0,0 +1,225 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorCommand_NoIssues(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "clean"})
+	require.NoError(t, err)
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"doctor"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "No issues found.")
+}
+
+func TestDoctorCommand_RegistersOrphanSpec(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "existing"})
+	require.NoError(t, err)
+
+	// Hand-copied config file
+	orphan := filepath.Join(cfg.WspDir(), "copied.yaml")
+	require.NoError(t, os.WriteFile(orphan, []byte("version: 1\nname: copied\n"), 0644))
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"doctor"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "orphan-spec")
+	require.Contains(t, string(output), "zest doctor --fix")
+
+	output, err = setupAndRun(rootCmd, tempDir, []string{"doctor", "--fix"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "Fixed 1 issue(s).")
+
+	output, err = setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "copied")
+}
+
+func TestDoctorCommand_RemovesDanglingEntry(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "gone"})
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(cfg.WspDir(), "gone.yaml")))
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"doctor", "--fix"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "dangling-entry")
+
+	output, err = setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "No workspaces found.")
+}
+
+func TestDoctorCommand_CleansStaleRuntimeAndDeadPIDs(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "dead"})
+	require.NoError(t, err)
+	_, err = setupAndRun(rootCmd, tempDir, []string{"launch", "dead"})
+	require.NoError(t, err)
+
+	// Processes that exited behind zest's back
+	rtFile := filepath.Join(cfg.RuntimeWspDir(), "dead.json")
+	require.NoError(t, os.WriteFile(rtFile, []byte(`{"name": "dead", "pids": [[99999999]]}`), 0644))
+
+	// Runtime file left behind for an unknown workspace
+	stale := filepath.Join(cfg.RuntimeWspDir(), "ghost.json")
+	require.NoError(t, os.WriteFile(stale, []byte(`{"name": "ghost"}`), 0644))
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"doctor", "--fix"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "dead-pids")
+	require.Contains(t, string(output), "stale-runtime")
+
+	for _, path := range []string{rtFile, stale} {
+		_, err = os.Stat(path)
+		require.True(t, os.IsNotExist(err))
+	}
+
+	output, err = setupAndRun(rootCmd, tempDir, []string{"list", "--filter", "inactive"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "dead")
+}
+
+func TestDoctorCommand_ReportsUnknownAppsAndMissingBinaries(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	for _, name := range []string{"unknown", "missing"} {
+		_, err := setupAndRun(rootCmd, tempDir, []string{"init", name})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.WspDir(), "unknown.yaml"), []byte(`
+version: 1
+name: unknown
+apps:
+  teleporter:
+    - name: beam
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.WspDir(), "missing.yaml"), []byte(`
+version: 1
+name: missing
+apps:
+  custom:
+    - name: nothere
+      cmd: zest-no-such-binary
+`), 0644))
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"doctor"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "invalid-spec")
+	require.Contains(t, string(output), "unknown app type 'teleporter'")
+	require.Contains(t, string(output), "missing-binary")
+	require.Contains(t, string(output), "zest-no-such-binary")
+}
+
+func TestDoctorCommand_ReportsUnsupportedApps(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("powershell runs on windows")
+	}
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	writeSpec(t, cfg, tempDir, "shell", "version: 1\napps:\n  terminal:\n    - tabs: [\"git status\"]\n    - tabs: [\"go test ./...\"]\n")
+
+	output, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"doctor"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "unsupported-app")
+	require.Equal(t, 1, strings.Count(string(output), ": powershell is unsupported on "+runtime.GOOS))
+	require.NotContains(t, string(output), "missing-binary")
+	require.NotContains(t, string(output), "'wt'")
+}
+
+func TestDoctorCommand_DanglingActiveEntryStopsApps(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the app below runs sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	writeSpec(t, cfg, tempDir, "gone", `version: 1
+apps:
+  custom:
+    - {id: sleeper, name: sleep, cmd: sleep, args: ["39"]}
+`)
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "gone"})
+	require.NoError(t, err)
+	pids := loadRuntime(t, cfg, "gone").PIDs[0]
+	require.NotEmpty(t, pids)
+
+	require.NoError(t, os.Remove(filepath.Join(cfg.WspDir(), "gone.yaml")))
+	output, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"doctor", "--fix"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "dangling-entry")
+
+	// the apps of the workspace don't outlive its entry
+	for _, pid := range pids {
+		require.Eventually(t, func() bool { return exited(pid) }, 5*time.Second, 100*time.Millisecond)
+	}
+}
+
+func TestDoctorCommand_DeadPIDsDropsExitedApps(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the app below runs sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	writeSpec(t, cfg, tempDir, "half", `version: 1
+apps:
+  custom:
+    - {id: alive, name: sleep, cmd: sleep, args: ["40"]}
+    - {id: dead, name: sleep, cmd: sleep, args: ["41"]}
+`)
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "half"})
+	require.NoError(t, err)
+	t.Cleanup(func() { setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "half"}) })
+
+	rt := loadRuntime(t, cfg, "half")
+	// pretend the second app exited behind zest's back
+	real := rt.PIDs[rt.FindApp("dead")]
+	t.Cleanup(func() { utils.Stop(real, 0) })
+	setRuntimeField(t, cfg, "half", "pids", [][]int{rt.PIDs[rt.FindApp("alive")], {99999999}})
+	setRuntimeField(t, cfg, "half", "apps", []string{"alive", "dead"})
+
+	output, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"doctor", "--fix"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "dead-pids")
+
+	output, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"app", "list", "half"})
+	require.NoError(t, err)
+	require.Regexp(t, `alive\s+sleep\s+running`, string(output))
+	require.Regexp(t, `dead\s+sleep\s+stopped`, string(output))
+	require.Equal(t, []string{"alive"}, loadRuntime(t, cfg, "half").Apps)
+}
@@ -0,0 +1,83 @@
+package test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditor_BackendsOpenFilesAtPosition(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	root := filepath.Join(tempDir, "api")
+	sock := filepath.Join(tempDir, "nvim.sock")
+
+	writeSpec(t, cfg, tempDir, "api", "version: 1\nworkspace_dir: "+filepath.ToSlash(root)+`
+apps:
+  neovim:
+    - files: [main.go:42:7, README.md, go.mod:3]
+      pane: tmux
+      listen: `+filepath.ToSlash(sock)+`
+  goland:
+    - files: [main.go:42:7]
+  zed:
+    - files: [main.go:42]
+  emacsclient:
+    - files: [main.go:42:7]
+`)
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api", "--dry-run"})
+	require.NoError(t, err)
+
+	main, readme, gomod := filepath.Join(root, "main.go"), filepath.Join(root, "README.md"), filepath.Join(root, "go.mod")
+	command := func(args ...string) string { return "Command: [" + utils.JoinQuoted(args) + "]" }
+	require.Contains(t, string(out), command("tmux", "new-window", "-n", "neovim", "-c", root, "--",
+		"nvim", "--listen", sock, "-c", "1argument | call cursor(42, 7) | 3argument | call cursor(3, 1) | 1argument",
+		main, readme, gomod))
+	require.Contains(t, string(out), command("goland", root))
+	require.Contains(t, string(out), command("goland", "--line", "42", "--column", "7", main))
+	require.Contains(t, string(out), command("zed", root, main+":42"))
+	require.Contains(t, string(out), command("emacsclient", "--no-wait", "--create-frame", "--alternate-editor=", "+42:7", main))
+}
+
+func TestEditor_BackendAndBinaryAreConfigurable(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "editor: subl\nbinaries:\n  pycharm: /opt/pycharm/bin/pycharm.sh\n")
+
+	writeSpec(t, cfg, tempDir, "py", `version: 1
+apps:
+  editor:
+    - path: /src/py
+      files: [app.py:10:2]
+  pycharm:
+    - path: /src/py
+    - path: /src/py
+      binary:
+        `+runtime.GOOS+`: /usr/local/bin/charm
+        default: pycharm
+`)
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "py", "--dry-run", "--only", "editor"})
+	require.NoError(t, err)
+
+	require.Contains(t, string(out), `- [subl] Opening folder: /src/py`)
+	require.Contains(t, string(out), `Command: ["subl", "/src/py", "/src/py/app.py:10:2"]`)
+	require.Contains(t, string(out), `Command: ["/opt/pycharm/bin/pycharm.sh", "/src/py"]`)
+	require.Contains(t, string(out), `Command: ["/usr/local/bin/charm", "/src/py"]`)
+}
+
+func TestEditor_RejectsNeovimOptionsElsewhere(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	writeSpec(t, cfg, tempDir, "bad", "version: 1\napps:\n  zed:\n    - listen: /tmp/zed.sock\n")
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "bad", "--dry-run"})
+	require.ErrorContains(t, err, "only supported by neovim")
+
+	writeSpec(t, cfg, tempDir, "bad", "version: 1\napps:\n  neovim:\n    - pane: screen\n")
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "bad", "--dry-run"})
+	require.ErrorContains(t, err, "pane must be terminal or tmux")
+}
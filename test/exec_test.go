@@ -0,0 +1,87 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain lets the test binary stand in for the commands zest exec and
+// zest shell run, so the tests don't depend on the tools of the platform.
+func TestMain(m *testing.M) {
+	if os.Getenv("ZEST_TEST_HELPER") == "1" {
+		wd, _ := os.Getwd()
+		fmt.Printf("workspace=%s mode=%s dir=%s args=%s\n",
+			os.Getenv("ZEST_WORKSPACE"), os.Getenv("MODE"), filepath.Base(wd), strings.Join(os.Args[1:], ","))
+		code, _ := strconv.Atoi(os.Getenv("ZEST_TEST_EXIT"))
+		os.Exit(code)
+	}
+	os.Exit(m.Run())
+}
+
+// runRaw runs root with args as given, for commands whose args after --
+// must not get the --custom flag setupAndRun appends.
+func runRaw(root *cobra.Command, args ...string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs(args)
+	err := root.Execute()
+	return buf.Bytes(), err
+}
+
+func execSpec(dir string) string {
+	return `version: 1
+workspace_dir: ` + filepath.ToSlash(dir) + `
+env:
+  MODE: dev
+apps: {}
+`
+}
+
+func TestExec_RunsInWorkspaceEnv(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	wspDir := filepath.Join(tempDir, "api")
+	require.NoError(t, os.MkdirAll(wspDir, 0755))
+	writeSpec(t, cfg, tempDir, "api", execSpec(wspDir))
+
+	t.Setenv("ZEST_TEST_HELPER", "1")
+	out, err := runRaw(cmd.NewRootCmd(cfg), "exec", "api", "--custom", tempDir, "--", os.Args[0], "a b", "-v")
+	require.NoError(t, err)
+	require.Equal(t, "workspace=api mode=dev dir=api args=a b,-v\n", string(out))
+
+	t.Setenv("ZEST_TEST_EXIT", "3")
+	_, err = runRaw(cmd.NewRootCmd(cfg), "exec", "api", "--custom", tempDir, "--", os.Args[0])
+	var exitErr *cmd.ExitCodeError
+	require.True(t, errors.As(err, &exitErr))
+	require.Equal(t, 3, exitErr.Code)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"exec", "api"})
+	require.ErrorContains(t, err, "the command after --")
+}
+
+func TestShell_SetsWorkspace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	wspDir := filepath.Join(tempDir, "api")
+	require.NoError(t, os.MkdirAll(wspDir, 0755))
+	writeSpec(t, cfg, tempDir, "api", execSpec(wspDir))
+
+	t.Setenv("ZEST_TEST_HELPER", "1")
+	t.Setenv("SHELL", os.Args[0])
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"shell", "api"})
+	require.NoError(t, err)
+	require.Equal(t, "workspace=api mode=dev dir=api args=\n", string(out))
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// gitRun runs git in dir and returns its trimmed output.
+func gitRun(t *testing.T, dir string, args ...string) string {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// setupRepo clones a repository with one commit on main and returns the
+// clone, whose main tracks the origin.
+func setupRepo(t *testing.T, tempDir string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "zest")
+	t.Setenv("GIT_AUTHOR_EMAIL", "zest@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "zest")
+	t.Setenv("GIT_COMMITTER_EMAIL", "zest@example.com")
+
+	origin := filepath.Join(tempDir, "origin")
+	require.NoError(t, os.MkdirAll(origin, 0755))
+	gitRun(t, origin, "init", "--quiet", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(origin, "README.md"), []byte("api\n"), 0644))
+	gitRun(t, origin, "add", ".")
+	gitRun(t, origin, "commit", "--quiet", "-m", "init")
+	gitRun(t, origin, "branch", "review")
+
+	repo := filepath.Join(tempDir, "api")
+	gitRun(t, tempDir, "clone", "--quiet", origin, repo)
+	return repo
+}
+
+func gitSpec(repo, git string) string {
+	return "version: 1\nworkspace_dir: " + filepath.ToSlash(repo) + "\ngit:\n" + git + "apps: {}\n"
+}
+
+func TestGit_SwitchesBranchOnLaunch(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	repo := setupRepo(t, tempDir)
+
+	// a branch only on the remote is checked out as a tracking branch
+	writeSpec(t, cfg, tempDir, "api", gitSpec(repo, "  branch: review\n  fetch: true\n"))
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Switching "+repo+" from 'main' to 'review'")
+	require.Equal(t, "review", gitRun(t, repo, "rev-parse", "--abbrev-ref", "HEAD"))
+	require.Equal(t, "origin/review", gitRun(t, repo, "rev-parse", "--abbrev-ref", "review@{upstream}"))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "api"})
+	require.NoError(t, err)
+
+	// uncommitted changes block a switch unless allowed
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("wip\n"), 0644))
+	writeSpec(t, cfg, tempDir, "api", gitSpec(repo, "  branch: feature/login\n"))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api"})
+	require.ErrorIs(t, err, launch.ErrDirtyWorktree)
+	require.Equal(t, "review", gitRun(t, repo, "rev-parse", "--abbrev-ref", "HEAD"))
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api", "--allow-dirty"})
+	require.NoError(t, err)
+	require.Equal(t, "feature/login", gitRun(t, repo, "rev-parse", "--abbrev-ref", "HEAD"))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "api"})
+	require.NoError(t, err)
+
+	// being on the branch already needs no clean checkout
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api"})
+	require.NoError(t, err)
+}
+
+func TestGit_AddsWorktree(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	repo := setupRepo(t, tempDir)
+	writeSpec(t, cfg, tempDir, "api", gitSpec(repo, "  branch: fix/crash\n  worktree: true\n"))
+
+	worktree := filepath.Join(tempDir, ".zest", "worktrees", "api", "fix-crash")
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api", "--dry-run"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Working Dir: "+worktree)
+	require.Contains(t, string(out), "Git: "+repo+" on 'fix/crash' in a worktree")
+	require.NoDirExists(t, worktree)
+
+	// the repository stays on its branch, even with changes
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("wip\n"), 0644))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api"})
+	require.NoError(t, err)
+	require.Equal(t, "main", gitRun(t, repo, "rev-parse", "--abbrev-ref", "HEAD"))
+	require.Equal(t, "fix/crash", gitRun(t, worktree, "rev-parse", "--abbrev-ref", "HEAD"))
+
+	// a relaunch reuses the worktree
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api", "--force"})
+	require.NoError(t, err)
+}
+
+func TestGit_WorktreeReadsEnvFilesFromRepo(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	repo := setupRepo(t, tempDir)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".env"), []byte("TOKEN=from-repo\n"), 0644))
+	writeSpec(t, cfg, tempDir, "api", gitSpec(repo, "  branch: fix/crash\n  worktree: true\nenv_files: [.env]\n"))
+
+	// the worktree doesn't exist before the first launch
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "api", "--dry-run"})
+	require.NoError(t, err)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "api"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "export TOKEN='from-repo';")
+}
+
+func TestGit_StatusVerboseShowsCheckout(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	repo := setupRepo(t, tempDir)
+	writeSpec(t, cfg, tempDir, "api", gitSpec(repo, "  branch: main\n"))
+	writeSpec(t, cfg, tempDir, "plain", "version: 1\nworkspace_dir: "+filepath.ToSlash(tempDir)+"\napps: {}\n")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0644))
+	gitRun(t, repo, "add", ".")
+	gitRun(t, repo, "commit", "--quiet", "-m", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("wip\n"), 0644))
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"status", "--verbose"})
+	require.NoError(t, err)
+	require.Regexp(t, `api\s+main\s+1\s+0\s+dirty\s+`+regexp.QuoteMeta(repo), string(out))
+	_, gitTable, _ := strings.Cut(string(out), "GIT\n")
+	require.NotContains(t, gitTable, "plain")
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"status", "--verbose", "--json"})
+	require.NoError(t, err)
+	var report cmd.StatusReport
+	require.NoError(t, json.Unmarshal(out, &report))
+	require.Equal(t, &launch.GitStatus{Dir: repo, Branch: "main", Upstream: "origin/main", Ahead: 1, Dirty: true}, report.Git["api"])
+}
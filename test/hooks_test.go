@@ -0,0 +1,258 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+const hooksSpec = `version: 1
+hooks:
+  pre_launch:
+    - cmd: echo fetching
+  post_launch:
+    - cmd: echo launched
+  pre_close:
+    - cmd: echo saving
+  post_close:
+    - cmd: echo backed-up
+apps: {}
+`
+
+func sessionLog(t *testing.T, cfg *utils.ZestConfig, name string) string {
+	data, err := os.ReadFile(filepath.Join(cfg.LogDir(), name+".log"))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestHooks_RunAroundLaunchAndClose(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "work", hooksSpec)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Running 1 pre_launch hook(s)...")
+
+	log := sessionLog(t, cfg, "work")
+	require.Contains(t, log, "== launch ")
+	require.Contains(t, log, "pre_launch: echo fetching\nfetching")
+	require.Contains(t, log, "post_launch: echo launched\nlaunched")
+	require.NotContains(t, log, "saving")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "work"})
+	require.NoError(t, err)
+
+	log = sessionLog(t, cfg, "work")
+	require.Contains(t, log, "== close ")
+	require.Contains(t, log, "pre_close: echo saving\nsaving")
+	require.Contains(t, log, "post_close: echo backed-up\nbacked-up")
+}
+
+func TestHooks_FailureStopsLaunch(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+hooks:
+  pre_launch:
+    - cmd: exit 3
+apps: {}
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.ErrorIs(t, err, launch.ErrHookFailed)
+	require.ErrorContains(t, err, "pre_launch 'exit 3'")
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "work"))
+	require.Contains(t, sessionLog(t, cfg, "work"), "'exit 3' failed")
+
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+hooks:
+  pre_launch:
+    - cmd: exit 3
+      continue_on_error: true
+apps: {}
+`)
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.NoError(t, err)
+	require.Equal(t, workspace.Active, wspStatus(t, cfg, "work"))
+	require.Contains(t, sessionLog(t, cfg, "work"), "'exit 3' failed, continuing")
+}
+
+func TestHooks_DryRunListsHooks(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", `version: 1
+hooks:
+  pre_launch:
+    - cmd: git fetch
+      cwd: src
+      continue_on_error: true
+apps:
+  terminal:
+    - id: db
+      hooks:
+        post_close:
+          - cmd: docker compose down
+`)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--dry-run"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Hooks:\n  pre_launch: git fetch (in src) [continue on error]\n")
+	require.Contains(t, string(out), "  Hooks:\n    post_close: docker compose down\n")
+
+	writeSpec(t, cfg, tempDir, "web", `version: 1
+hooks:
+  pre_close:
+    - timeout: 5s
+apps: {}
+`)
+	_, err = launch.NewLaunchPlan(cfg, "web", "")
+	require.ErrorContains(t, err, "pre_close: hook without cmd")
+}
+
+func TestHooks_EnvAndTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands below are written for sh")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "src", "marker.txt"), nil, 0644))
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+workspace_dir: `+tempDir+`
+env:
+  MODE: dev
+hooks:
+  pre_launch:
+    - cmd: echo "$MODE $EXTRA $(ls)"
+      cwd: src
+      env:
+        EXTRA: yes
+  post_launch:
+    - cmd: sleep 5
+      timeout: 100ms
+apps: {}
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.ErrorIs(t, err, launch.ErrHookFailed)
+	require.ErrorContains(t, err, "timed out after 100ms")
+
+	// post_launch failures leave the workspace running
+	require.Equal(t, workspace.Active, wspStatus(t, cfg, "work"))
+	require.Contains(t, sessionLog(t, cfg, "work"), "dev yes marker.txt")
+}
+
+func TestHooks_AppPostLaunchFailureKeepsAppTracked(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the app below runs sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+apps:
+  custom:
+    - id: sleeper
+      name: sleep
+      cmd: sleep
+      args: ["30"]
+      hooks:
+        post_launch:
+          - cmd: exit 3
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.ErrorIs(t, err, launch.ErrPostLaunch)
+	require.ErrorIs(t, err, launch.ErrHookFailed)
+	require.ErrorContains(t, err, "workspace 'work' launched, but")
+
+	// the app runs, so the workspace is active and knows its pids
+	require.Equal(t, workspace.Active, wspStatus(t, cfg, "work"))
+	rt := loadRuntime(t, cfg, "work")
+	require.Equal(t, []string{"sleeper"}, rt.Apps)
+	require.Len(t, rt.PIDs, 1)
+	require.NotEmpty(t, rt.PIDs[0])
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "work"})
+	require.NoError(t, err)
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "work"))
+}
+
+func TestHooks_TimeoutKillsHookChildren(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands below are written for sh")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	pidFile := filepath.Join(tempDir, "child.pid")
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+hooks:
+  post_launch:
+    - cmd: sleep 61 & echo $! > `+pidFile+`; wait
+      timeout: 300ms
+apps: {}
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.ErrorContains(t, err, "timed out after 300ms")
+
+	data, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return exited(pid) }, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestHooks_PreCloseFailureStillStopsApps(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the app below runs sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+hooks:
+  pre_close:
+    - cmd: exit 3
+  post_close:
+    - cmd: echo backed-up
+apps:
+  custom:
+    - id: sleeper
+      name: sleep
+      cmd: sleep
+      args: ["62"]
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.NoError(t, err)
+	rt := loadRuntime(t, cfg, "work")
+	require.Len(t, rt.PIDs, 1)
+	pids := rt.PIDs[0]
+
+	output, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "work"})
+	require.ErrorIs(t, err, launch.ErrHookFailed)
+	require.ErrorContains(t, err, "workspace 'work' closed, but")
+	require.Contains(t, string(output), "closing anyway")
+
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "work"))
+	for _, pid := range pids {
+		require.Eventually(t, func() bool { return exited(pid) }, 2*time.Second, 20*time.Millisecond)
+	}
+	require.Contains(t, sessionLog(t, cfg, "work"), "backed-up")
+}
@@ -0,0 +1,138 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSpec initializes a workspace and replaces its spec with content.
+func writeSpec(t *testing.T, cfg *utils.ZestConfig, tempDir, name, content string) {
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", name, "--force"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.WspDir(), name+".yaml"), []byte(content), 0644))
+}
+
+func TestInherit_ExtendsAndIncludesMerge(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	writeSpec(t, cfg, tempDir, "base", `version: 1
+name: base
+workspace_dir: /src/base
+env:
+  MODE: base
+  SHARED: "yes"
+apps:
+  brave:
+    - id: chat
+      tabs: ["https://chat.example.com"]
+    - tabs: ["https://mail.example.com"]
+`)
+	fragment := filepath.Join(cfg.WspDir(), "fragments", "notes.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(fragment), 0755))
+	require.NoError(t, os.WriteFile(fragment, []byte(`env:
+  NOTES: on
+apps:
+  vscode:
+    - id: notes
+      path: /notes
+`), 0644))
+
+	writeSpec(t, cfg, tempDir, "child", `version: 1
+name: child
+extends: base
+include:
+  - fragments/notes.yaml
+env:
+  MODE: child
+apps:
+  brave:
+    - id: chat
+      tabs: ["https://chat.example.com/team"]
+`)
+
+	cfg.ZestDir = tempDir
+	plan, err := launch.NewLaunchPlan(cfg, "child", "")
+	require.NoError(t, err)
+
+	require.Equal(t, "child", plan.Name)
+	require.Equal(t, "/src/base", plan.WorkingDir)
+	require.Equal(t, "child", plan.Env["MODE"])
+	require.Equal(t, "yes", plan.Env["SHARED"])
+	require.Equal(t, "on", plan.Env["NOTES"])
+	require.Len(t, plan.Apps, 3)
+	require.Equal(t, []string{
+		filepath.Join(cfg.WspDir(), "base.yaml"),
+		fragment,
+		filepath.Join(cfg.WspDir(), "child.yaml"),
+	}, plan.Sources)
+
+	summary := plan.Summary()
+	require.Contains(t, summary, "https://chat.example.com/team")
+	require.NotContains(t, summary, `"https://chat.example.com"`)
+	require.Contains(t, summary, "https://mail.example.com")
+	require.Contains(t, summary, "Opening folder: /notes")
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "child", "--dry-run"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Resolved From:")
+	require.Contains(t, string(out), "Flattened spec:")
+	require.NotContains(t, string(out), "extends:")
+
+	// fragments are not mistaken for workspaces
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"doctor"})
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "fragments")
+}
+
+func TestInherit_ExtendsTemplateWithVars(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	writeSpec(t, cfg, tempDir, "api", `version: 1
+name: api
+extends: template:go-dev
+vars:
+  repo: /src/api
+apps:
+  brave:
+    - tabs: ["https://api.example.com"]
+`)
+
+	cfg.ZestDir = tempDir
+	plan, err := launch.NewLaunchPlan(cfg, "api", "")
+	require.NoError(t, err)
+	require.Equal(t, "api", plan.Name)
+	require.Equal(t, "/src/api", plan.WorkingDir)
+	require.Contains(t, plan.Summary(), "https://pkg.go.dev/std")
+	require.Contains(t, plan.Summary(), "https://api.example.com")
+
+	// required template variables must be set
+	writeSpec(t, cfg, tempDir, "novars", "version: 1\nextends: go-dev\n")
+	_, err = launch.NewLaunchPlan(cfg, "novars", "")
+	require.ErrorContains(t, err, "needs a value for 'repo'")
+}
+
+func TestInherit_DetectsCycles(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	writeSpec(t, cfg, tempDir, "a", "version: 1\nextends: b\n")
+	writeSpec(t, cfg, tempDir, "b", "version: 1\ninclude: [fragments/c.yaml]\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(cfg.WspDir(), "fragments"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.WspDir(), "fragments", "c.yaml"), []byte("extends: a\n"), 0644))
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "a", "--dry-run"})
+	require.ErrorIs(t, err, launch.ErrInheritanceCycle)
+	require.ErrorContains(t, err, "a.yaml -> ")
+
+	writeSpec(t, cfg, tempDir, "self", "version: 1\nextends: self\n")
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "self", "--dry-run"})
+	require.ErrorIs(t, err, launch.ErrInheritanceCycle)
+}
@@ -115,3 +115,75 @@ func TestInitCommand_ForceCreateExistingWsp(t *testing.T) {
 	err := cmd.Execute()
 	require.NoError(t, err)
 }
+
+func TestInitCommand_WritesSpecWithoutMetadata(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	cmd := cmd.NewRootCmd(cfg)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"init", "work", "--custom", tempDir})
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(filepath.Join(cfg.WspDir(), "work.yaml"))
+	require.NoError(t, err)
+
+	spec := string(data)
+	require.Contains(t, spec, "version: 1")
+	require.Contains(t, spec, "name: work")
+	for _, key := range []string{"status:", "created:", "last_updated:", "last_used:", "path:"} {
+		require.NotContains(t, spec, key)
+	}
+}
+
+func TestInitCommand_MigratesLegacySpec(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	cmd := cmd.NewRootCmd(cfg)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"init", "legacy", "--custom", tempDir})
+	require.NoError(t, cmd.Execute())
+
+	// Overwrite with a spec written by an older zest
+	wspFile := filepath.Join(cfg.WspDir(), "legacy.yaml")
+	legacy := []byte(`name: legacy
+path: ` + wspFile + `
+status: inactive
+workspace_dir: /tmp
+created: "2025-01-01T00:00:00Z"
+last_updated: "2025-01-01T00:00:00Z"
+last_used: never
+apps:
+  custom:
+    - name: echoapp
+      cmd: echo
+`)
+	require.NoError(t, os.WriteFile(wspFile, legacy, 0644))
+
+	cmd.SetArgs([]string{"list", "--custom", tempDir})
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(wspFile)
+	require.NoError(t, err)
+
+	spec := string(data)
+	require.Contains(t, spec, "version: 1")
+	require.Contains(t, spec, "workspace_dir: /tmp")
+	require.Contains(t, spec, "echoapp")
+	require.NotContains(t, spec, "status:")
+	require.NotContains(t, spec, "last_used:")
+
+	original, err := os.ReadFile(wspFile + ".v0.bak")
+	require.NoError(t, err)
+	require.Equal(t, legacy, original)
+
+	// the migrated spec is left alone from then on
+	cmd.SetArgs([]string{"list", "--custom", tempDir})
+	require.NoError(t, cmd.Execute())
+	again, err := os.ReadFile(wspFile)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+}
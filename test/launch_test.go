@@ -5,10 +5,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/AVAniketh0905/zest/cmd"
 	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/shirou/gopsutil/process"
 	"github.com/stretchr/testify/require"
 )
 
@@ -207,3 +212,75 @@ func TestLaunchCommand_ForceLaunchesActiveWorkspace(t *testing.T) {
 	cmd.SetErr(io.Discard)
 	require.NoError(t, cmd.Execute())
 }
+
+// exited reports whether a process is gone, zombies left behind by the
+// test process count as gone.
+func exited(pid int) bool {
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return true
+	}
+	status, err := p.Status()
+	return err != nil || status == "Z"
+}
+
+func TestLaunchCommand_ParallelAppsKeepTheirOwnPIDs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the apps below run sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "launch:\n  parallelism: 4\nclose:\n  grace_period: 0s\n")
+	writeSpec(t, cfg, tempDir, "dev", `version: 1
+apps:
+  custom:
+    - {id: a, name: sleep, cmd: sleep, args: ["30"]}
+    - {id: b, name: sleep, cmd: sleep, args: ["31"]}
+    - {id: c, name: sleep, cmd: sleep, args: ["32"]}
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "dev"})
+	require.NoError(t, err)
+	t.Cleanup(func() { setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "dev"}) })
+
+	rt := loadRuntime(t, cfg, "dev")
+	seen := map[int]bool{}
+	for i, pids := range rt.PIDs {
+		require.Len(t, pids, 1, rt.Apps[i])
+		require.False(t, seen[pids[0]], "pid %d recorded twice", pids[0])
+		seen[pids[0]] = true
+	}
+}
+
+func TestLaunchCommand_FailureStopsStartedApps(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the app below runs sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	writeSpec(t, cfg, tempDir, "dev", `version: 1
+apps:
+  custom:
+    - {id: a, name: sleep, cmd: sleep, args: ["33"]}
+    - {id: b, name: nothere, cmd: zest-no-such-binary}
+`)
+
+	before, err := utils.ListPIDs("sleep")
+	require.NoError(t, err)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "dev"})
+	require.ErrorContains(t, err, "failed to launch workspace 'dev'")
+	require.Contains(t, string(out), "Stopping the apps that did start...")
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "dev"))
+
+	after, err := utils.ListPIDs("sleep")
+	require.NoError(t, err)
+	for _, pid := range after {
+		if !slices.Contains(before, pid) {
+			require.Eventually(t, func() bool { return exited(pid) }, 5*time.Second, 100*time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const profileSpec = `version: 1
+name: work
+env:
+  MODE: dev
+apps:
+  brave:
+    - id: chat
+      tabs: ["https://chat.example.com"]
+    - id: docs
+      enabled: false
+      tabs: ["https://docs.example.com"]
+  vscode:
+    - path: /src/work
+profiles:
+  minimal:
+    disable: [brave, vscode]
+  review:
+    enable: [docs]
+    disable: [chat]
+    override:
+      env:
+        MODE: review
+`
+
+func TestProfile_SelectsAndOverridesApps(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "work", profileSpec)
+
+	plan, err := launch.NewLaunchPlan(cfg, "work", "")
+	require.NoError(t, err)
+	require.Len(t, plan.Apps, 2)
+	require.NotContains(t, plan.Summary(), "docs.example.com")
+
+	plan, err = launch.NewLaunchPlan(cfg, "work", "review")
+	require.NoError(t, err)
+	require.Len(t, plan.Apps, 2)
+	require.Equal(t, "review", plan.Env["MODE"])
+	summary := plan.Summary()
+	require.Contains(t, summary, "Profile: review")
+	require.Contains(t, summary, "docs.example.com")
+	require.NotContains(t, summary, "chat.example.com")
+
+	plan, err = launch.NewLaunchPlan(cfg, "work", "minimal")
+	require.NoError(t, err)
+	require.Empty(t, plan.Apps)
+
+	_, err = launch.NewLaunchPlan(cfg, "work", "full")
+	require.ErrorIs(t, err, launch.ErrProfileNotExists)
+	require.ErrorContains(t, err, "available: minimal, review")
+}
+
+func TestProfile_RecordedInRuntimeAndStatus(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "work", profileSpec)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work", "--profile", "nope"})
+	require.ErrorIs(t, err, launch.ErrProfileNotExists)
+	require.NotContains(t, string(out), "launched successfully")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work", "--profile", "minimal"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(cfg.RuntimeWspDir(), "work.json"))
+	require.NoError(t, err)
+	var rt map[string]any
+	require.NoError(t, json.Unmarshal(data, &rt))
+	require.Equal(t, "minimal", rt["profile"])
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"status"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "PROFILE")
+	require.Contains(t, string(out), "minimal")
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+const projectSpec = `version: 1
+env:
+  MODE: dev
+tasks:
+  test:
+    cmd: go test ./...
+apps: {}
+`
+
+// setupProject creates a repository holding a project file, with a nested
+// directory to run zest from.
+func setupProject(t *testing.T, tempDir, name, content string) string {
+	repo, err := filepath.EvalSymlinks(tempDir)
+	require.NoError(t, err)
+	repo = filepath.Join(repo, "src", name)
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "internal"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, launch.ProjectFile), []byte(content), 0644))
+	return repo
+}
+
+func TestProject_LaunchNeedsAllow(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	repo := setupProject(t, tempDir, "my.api", projectSpec)
+	file := filepath.Join(repo, launch.ProjectFile)
+	t.Chdir(filepath.Join(repo, "internal"))
+
+	// found from a nested directory and registered, but not trusted yet
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+	require.ErrorIs(t, err, launch.ErrNotAllowed)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"allow"})
+	require.NoError(t, err)
+	require.Equal(t, "Allowed "+file+".\n", string(out))
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Launching workspace 'my-api'")
+	require.Contains(t, string(out), "Working Dir: "+repo)
+
+	reg, err := workspace.NewWspRegistry(cfg)
+	require.NoError(t, err)
+	wspCfg, ok := reg.GetCfg("my-api")
+	require.True(t, ok)
+	require.Equal(t, file, wspCfg.Project)
+
+	// the workspace works by name too, and a change needs a new allow
+	require.NoError(t, os.WriteFile(file, []byte(projectSpec+"hooks:\n  pre_launch:\n    - cmd: echo hi\n"), 0644))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "my-api", "--list"})
+	require.ErrorIs(t, err, launch.ErrNotAllowed)
+	require.ErrorContains(t, err, "changed since it was allowed")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"allow", repo})
+	require.NoError(t, err)
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "my-api", "--list"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "test")
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"allow", file, "--revoke"})
+	require.NoError(t, err)
+	require.Equal(t, "Revoked "+file+".\n", string(out))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+	require.ErrorIs(t, err, launch.ErrNotAllowed)
+}
+
+func TestProject_RegisterOnce(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	named := "version: 1\nname: shop\nworkspace_dir: web\napps: {}\n"
+	repo := setupProject(t, tempDir, "shop-v1", named)
+	other := setupProject(t, tempDir, "shop-v2", named)
+
+	t.Chdir(repo)
+	for range 2 {
+		_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"allow"})
+		require.NoError(t, err)
+		out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+		require.NoError(t, err)
+		require.Contains(t, string(out), "Launching workspace 'shop'")
+		require.Contains(t, string(out), "Working Dir: "+filepath.Join(repo, "web"))
+	}
+
+	// another clone can't take the name of a registered workspace
+	t.Chdir(other)
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceExists)
+
+	t.Chdir(tempDir)
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch"})
+	require.ErrorIs(t, err, workspace.ErrNoProjectFile)
+}
+
+func TestProject_IncludeNeedsAllow(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	repo := setupProject(t, tempDir, "api", "version: 1\ninclude:\n  - hooks.yaml\napps: {}\n")
+	fragment := filepath.Join(repo, "hooks.yaml")
+	require.NoError(t, os.WriteFile(fragment, []byte("tasks:\n  test:\n    cmd: go test ./...\n"), 0644))
+	t.Chdir(repo)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"allow"})
+	require.NoError(t, err)
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+	require.NoError(t, err)
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "--list"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "test")
+
+	// the fragment changes, the project file doesn't
+	require.NoError(t, os.WriteFile(fragment, []byte("hooks:\n  pre_launch:\n    - cmd: echo hi\n"), 0644))
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+	require.ErrorIs(t, err, launch.ErrNotAllowed)
+	require.ErrorContains(t, err, "changed since it was allowed")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"allow"})
+	require.NoError(t, err)
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "--dry-run"})
+	require.NoError(t, err)
+}
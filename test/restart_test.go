@@ -0,0 +1,100 @@
+package test
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestart_KeepsLaunchOptions(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"restart", "web"})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceIsInactive)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none", "--env", "MODE=dev", "--skip", "browser"})
+	require.NoError(t, err)
+
+	rt := loadRuntime(t, cfg, "web")
+	require.Equal(t, "none", rt.Launch.Profile)
+	require.Equal(t, map[string]string{"MODE": "dev"}, rt.Launch.Env)
+	require.Equal(t, []string{"browser"}, rt.Launch.Filter.Skip)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"restart", "web"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Restarting workspace 'web'")
+	require.Contains(t, string(out), "MODE:dev")
+	require.Contains(t, string(out), "Workspace 'web' launched successfully.")
+
+	rt = loadRuntime(t, cfg, "web")
+	require.Equal(t, "none", rt.Profile)
+	require.Equal(t, map[string]string{"MODE": "dev"}, rt.Launch.Env)
+	require.Equal(t, []string{"browser"}, rt.Launch.Filter.Skip)
+	require.Empty(t, rt.Apps)
+}
+
+func TestRestart_AppsMustExist(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+	setRuntimeApps(t, cfg, "web", "full", []string{"server"}, [][]int{{os.Getpid()}})
+
+	// nothing is stopped when an app is unknown
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"restart", "web", "server", "chat", "--rolling"})
+	require.ErrorIs(t, err, launch.ErrNoMatchingApp)
+	require.Equal(t, []string{"server"}, loadRuntime(t, cfg, "web").Apps)
+
+	setRuntimeApps(t, cfg, "web", "full", nil, [][]int{{}})
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"restart", "web", "--rolling"})
+	require.ErrorIs(t, err, workspace.ErrUntrackedApps)
+}
+
+func TestRestart_WaitReady(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	require.NoError(t, closed.Close())
+
+	writeSpec(t, cfg, tempDir, "svc", `version: 1
+apps:
+  terminal:
+    - id: up
+      ready:
+        port: `+strconv.Itoa(port)+`
+    - id: down
+      ready:
+        port: `+strconv.Itoa(closedPort)+`
+        timeout: 300ms
+`)
+
+	plan, err := launch.NewLaunchPlan(cfg, "svc", "")
+	require.NoError(t, err)
+
+	up, ok := plan.App("up")
+	require.True(t, ok)
+	require.NoError(t, launch.WaitReady(up))
+	require.NoError(t, ln.Close())
+
+	down, ok := plan.App("down")
+	require.True(t, ok)
+	require.ErrorIs(t, launch.WaitReady(down), launch.ErrNotReady)
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksSpec = `version: 1
+env:
+  MODE: dev
+tasks:
+  build:
+    cmd: echo building
+    description: Compile the service
+  seed:
+    cmd: echo seeding
+    deps: [migrate]
+  migrate:
+    cmd: echo migrating
+    deps: [build]
+  fail:
+    cmd: exit 4
+apps: {}
+`
+
+func TestRun_ListsTasks(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "api", tasksSpec)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "--list"})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 5)
+	require.Equal(t, []string{"TASK", "DEPS", "DESCRIPTION"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"build", "-", "Compile", "the", "service"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"seed", "migrate"}, strings.Fields(lines[4]))
+}
+
+func TestRun_RunsDependenciesFirst(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "api", tasksSpec)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "seed"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"building", "migrating", "seeding"}, strings.Fields(string(out)))
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "deploy"})
+	require.ErrorIs(t, err, launch.ErrTaskNotExists)
+	require.ErrorContains(t, err, "available: build, fail, migrate, seed")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "build", "extra"})
+	require.ErrorContains(t, err, "pass task arguments after --")
+}
+
+func TestRun_PassesExitCode(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "api", tasksSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "fail"})
+	var exitErr *cmd.ExitCodeError
+	require.True(t, errors.As(err, &exitErr))
+	require.Equal(t, 4, exitErr.Code)
+}
+
+func TestRun_RejectsCycles(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "api", `version: 1
+tasks:
+  a:
+    cmd: echo a
+    deps: [b]
+  b:
+    cmd: echo b
+    deps: [a]
+  c:
+    cmd: echo c
+    deps: [missing]
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "a"})
+	require.ErrorIs(t, err, launch.ErrTaskCycle)
+	require.ErrorContains(t, err, "a -> b -> a")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"run", "api", "c"})
+	require.ErrorContains(t, err, "'missing', needed by 'c'")
+}
+
+func TestRun_ArgsAndEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("task commands below are written for sh")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "api", `version: 1
+env:
+  MODE: dev
+tasks:
+  test:
+    cmd: printf '%s|' "$MODE" "$LEVEL"
+    env:
+      LEVEL: debug
+`)
+
+	// --custom has to come before the task arguments
+	out := &bytes.Buffer{}
+	root := cmd.NewRootCmd(cfg)
+	root.SetOut(out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"run", "api", "test", "--custom", tempDir, "--", "-run", "it's a test"})
+	require.NoError(t, root.Execute())
+	require.Equal(t, "dev|debug|-run|it's a test|", out.String())
+}
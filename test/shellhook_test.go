@@ -0,0 +1,156 @@
+package test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/shellhook"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func dirSpec(dir, env string) string {
+	return "version: 1\nworkspace_dir: " + filepath.ToSlash(dir) + "\n" + env + "apps: {}\n"
+}
+
+func TestEnv_MatchesLongestWorkspaceDir(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	src := filepath.Join(tempDir, "src")
+	api := filepath.Join(src, "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(api, "cmd"), 0755))
+	writeSpec(t, cfg, tempDir, "src", dirSpec(src, ""))
+	writeSpec(t, cfg, tempDir, "api", dirSpec(api, ""))
+
+	which := func(dir string) string {
+		out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "--which", "--dir", dir})
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	require.Equal(t, "api inactive\n", which(filepath.Join(api, "cmd")))
+	require.Equal(t, "api inactive\n", which(api))
+	require.Equal(t, "src inactive\n", which(src))
+	require.Equal(t, "", which(tempDir))
+	// a sibling that only shares the prefix of the name
+	require.Equal(t, "src inactive\n", which(api+"-old"))
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "--dir", tempDir})
+	require.ErrorIs(t, err, cmd.ErrNoWorkspaceForDir)
+}
+
+func TestEnv_MatchIgnoresEnvFiles(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	api := filepath.Join(tempDir, "api")
+	require.NoError(t, os.MkdirAll(api, 0755))
+	writeSpec(t, cfg, tempDir, "api", dirSpec(api, "env_files: [missing.env]\n"))
+
+	// only workspace_dir is read to find the workspace
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "--which", "--dir", api})
+	require.NoError(t, err)
+	require.Equal(t, "api inactive\n", string(out))
+
+	// and exporting its env reports the broken env file instead of finding nothing
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "--dir", api})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, cmd.ErrNoWorkspaceForDir)
+	require.ErrorContains(t, err, "missing.env")
+}
+
+func TestEnv_ExportsWorkspaceEnv(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	api := filepath.Join(tempDir, "api")
+	require.NoError(t, os.MkdirAll(api, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(api, ".env"), []byte("TOKEN=from-file\n"), 0644))
+	writeSpec(t, cfg, tempDir, "api", dirSpec(api, "env:\n  MODE: it's dev\n  TOKEN: from-spec\nenv_files: [.env]\n"))
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "api"})
+	require.NoError(t, err)
+	require.Equal(t, "export MODE='it'\\''s dev';\nexport TOKEN='from-file';\nexport ZEST_WORKSPACE='api';\n", string(out))
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "--dir", api, "--shell", "fish"})
+	require.NoError(t, err)
+	require.Equal(t, "set -gx MODE 'it\\'s dev';\nset -gx TOKEN 'from-file';\nset -gx ZEST_WORKSPACE 'api';\n", string(out))
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"env", "api", "--shell", "tcsh"})
+	require.ErrorIs(t, err, shellhook.ErrUnsupportedShell)
+}
+
+func TestHook_PrintsShellScripts(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	for _, shell := range shellhook.Shells {
+		for _, onEnter := range shellhook.OnEnterActions {
+			out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"hook", shell, "--on-enter", onEnter})
+			require.NoError(t, err)
+			require.Contains(t, string(out), "_zest_prompt")
+			require.Contains(t, string(out), "zest env --which")
+
+			// the script must at least parse in the shell it is for
+			if bin, err := exec.LookPath(shell); err == nil {
+				script := filepath.Join(tempDir, "hook."+shell)
+				require.NoError(t, os.WriteFile(script, out, 0644))
+				res, err := exec.Command(bin, "-n", script).CombinedOutput()
+				require.NoError(t, err, string(res))
+			}
+		}
+	}
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"hook", "bash", "--on-enter", "launch", "--no-prompt"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), `command zest launch "$wsp" 2>/dev/null`)
+	require.NotContains(t, string(out), "PS1")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"hook", "bash", "--on-enter", "always"})
+	require.ErrorIs(t, err, shellhook.ErrInvalidOnEnter)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"hook", "tcsh"})
+	require.Error(t, err)
+}
+
+func TestHook_OnEnterKeepsZestQuiet(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil || runtime.GOOS == "windows" {
+		t.Skip("needs bash")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	// a zest that matches every directory, is noisy on stderr and fails to launch
+	bin := filepath.Join(tempDir, "bin")
+	require.NoError(t, os.MkdirAll(bin, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "zest"), []byte(`#!/bin/sh
+echo noise >&2
+case "$1 $2" in
+  "env --which") echo "api inactive" ;;
+  env*) echo "export MODE=dev" ;;
+  launch*) exit 1 ;;
+esac
+`), 0755))
+
+	for onEnter, want := range map[string]string{
+		"env":    "",
+		"launch": "zest: failed to launch 'api', run 'zest launch api' to see why.\n",
+	} {
+		out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"hook", "bash", "--on-enter", onEnter})
+		require.NoError(t, err)
+		script := filepath.Join(tempDir, "hook.bash")
+		require.NoError(t, os.WriteFile(script, out, 0644))
+
+		run := exec.Command(bash, "-c", `source "$1"; cd /; _zest_hook`, "bash", script)
+		run.Env = append(os.Environ(), "PATH="+bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+		var stderr bytes.Buffer
+		run.Stderr = &stderr
+		require.NoError(t, run.Run())
+		require.Equal(t, want, stderr.String(), onEnter)
+	}
+}
@@ -0,0 +1,209 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_RecoversCorruptRegistryFromBackup(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	for _, name := range []string{"first", "second"} {
+		_, err := setupAndRun(rootCmd, tempDir, []string{"init", name})
+		require.NoError(t, err)
+	}
+
+	// Simulate a crash halfway through a write
+	regFile := filepath.Join(cfg.StateDir(), "workspaces.json")
+	require.NoError(t, os.WriteFile(regFile, []byte(`{"workspaces": {"fir`), 0644))
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "first")
+
+	// The registry file itself is repaired
+	_, err = setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+	data, err := os.ReadFile(regFile)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"first"`)
+}
+
+func TestState_FailsWithoutValidBackup(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "only"})
+	require.NoError(t, err)
+
+	regFile := filepath.Join(cfg.StateDir(), "workspaces.json")
+	require.NoError(t, os.WriteFile(regFile, []byte(`{`), 0644))
+	require.NoError(t, os.WriteFile(regFile+".bak", []byte(`{`), 0644))
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no valid backup")
+}
+
+func TestState_WritesLeaveNoTempFiles(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "clean"})
+	require.NoError(t, err)
+	_, err = setupAndRun(rootCmd, tempDir, []string{"launch", "clean"})
+	require.NoError(t, err)
+
+	for _, dir := range []string{cfg.StateDir(), cfg.RuntimeWspDir()} {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.tmp-*"))
+		require.NoError(t, err)
+		require.Empty(t, matches)
+	}
+}
+
+func TestState_MigratesUnversionedRegistry(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "old"})
+	require.NoError(t, err)
+
+	// Registry as written before schema versioning existed
+	regFile := filepath.Join(cfg.StateDir(), "workspaces.json")
+	legacy := `{"workspaces": {"old": {"name": "old", "path": "` + filepath.ToSlash(filepath.Join(cfg.WspDir(), "old.yaml")) + `",
+		"status": "inactive", "workspace_dir": "/tmp", "created": "", "last_updated": "", "last_used": "never"}}}`
+	require.NoError(t, os.WriteFile(regFile, []byte(legacy), 0644))
+
+	output, err := setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.NoError(t, err)
+	require.Contains(t, string(output), "old")
+
+	data, err := os.ReadFile(regFile)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"schema_version": 1`)
+	require.NotContains(t, string(data), "workspace_dir")
+
+	original, err := os.ReadFile(regFile + ".v0.bak")
+	require.NoError(t, err)
+	require.Equal(t, legacy, string(original))
+}
+
+func TestState_RejectsNewerSchemaVersion(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "future"})
+	require.NoError(t, err)
+
+	regFile := filepath.Join(cfg.StateDir(), "workspaces.json")
+	future := []byte(`{"schema_version": 99, "workspaces": {}}`)
+	require.NoError(t, os.WriteFile(regFile, future, 0644))
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"list"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "newer version of zest")
+
+	// The file is left exactly as it was
+	data, err := os.ReadFile(regFile)
+	require.NoError(t, err)
+	require.Equal(t, future, data)
+}
+
+func TestState_SaveKeepsNewerSchemaVersion(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	require.NoError(t, os.MkdirAll(cfg.RuntimeWspDir(), os.ModePerm))
+
+	wspRt, err := workspace.NewWspRuntime(cfg, "work")
+	require.NoError(t, err)
+	require.NoError(t, wspRt.Load())
+
+	// a newer zest writes the file after it was loaded
+	future := []byte(`{"schema_version": 99, "name": "work"}`)
+	require.NoError(t, os.WriteFile(wspRt.RtFile, future, 0644))
+
+	require.NoError(t, wspRt.SetApp("editor", "code", "hash", "env", []int{1000}))
+	err = wspRt.Save()
+	require.ErrorIs(t, err, workspace.ErrSchemaTooNew)
+
+	data, err := os.ReadFile(wspRt.RtFile)
+	require.NoError(t, err)
+	require.Equal(t, future, data)
+}
+
+func TestState_RuntimeTransactionsDontLoseApps(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	require.NoError(t, os.MkdirAll(cfg.RuntimeWspDir(), os.ModePerm))
+	launched, err := workspace.NewWspRuntime(cfg, "work")
+	require.NoError(t, err)
+	require.NoError(t, launched.Save())
+
+	// zest processes starting apps of the same workspace at the same time
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wspRt, err := workspace.NewWspRuntime(cfg, "work")
+			require.NoError(t, err)
+			err = wspRt.Transact(func() error {
+				time.Sleep(time.Millisecond)
+				return wspRt.SetApp(fmt.Sprintf("app-%d", i), "proc", "hash", "env", []int{1000 + i})
+			})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	rt := loadRuntime(t, cfg, "work")
+	require.Len(t, rt.Apps, 20)
+	require.Len(t, rt.PIDs, 20)
+	require.Len(t, rt.Hashes, 20)
+}
+
+func TestState_TransactDoesntRecreateDeletedRuntime(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	require.NoError(t, os.MkdirAll(cfg.RuntimeWspDir(), os.ModePerm))
+
+	wspRt, err := workspace.NewWspRuntime(cfg, "work")
+	require.NoError(t, err)
+	require.NoError(t, wspRt.Save())
+
+	// `zest close` deletes the runtime while an app start waits for the lock
+	closer, err := workspace.NewWspRuntime(cfg, "work")
+	require.NoError(t, err)
+	require.NoError(t, closer.Delete())
+
+	// nothing is left behind in the state directory
+	entries, err := os.ReadDir(cfg.RuntimeWspDir())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	err = wspRt.Transact(func() error {
+		return wspRt.SetApp("editor", "code", "hash", "env", []int{1000})
+	})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceIsInactive)
+	require.NoFileExists(t, wspRt.RtFile)
+}
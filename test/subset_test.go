@@ -0,0 +1,174 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const subsetSpec = `version: 1
+name: web
+apps:
+  vscode:
+    - path: /src/web
+      tags: [frontend, backend]
+  terminal:
+    - id: server
+      tabs: ["npm run dev"]
+      tags: [frontend]
+    - id: db
+      tabs: ["docker compose up db"]
+      tags: [backend]
+  browser:
+    - tabs: ["http://localhost:3000"]
+      tags: [frontend]
+profiles:
+  none:
+    disable: [vscode, terminal, browser]
+  full: {}
+`
+
+func TestSubset_FiltersPlanApps(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	plan, err := launch.NewLaunchPlan(cfg, "web", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"browser", "server", "db", "vscode"}, plan.GetAppIDs())
+
+	filtered := func(f launch.AppFilter) []string {
+		plan, err := launch.NewLaunchPlan(cfg, "web", "")
+		require.NoError(t, err)
+		require.NoError(t, plan.Filter(f))
+		return plan.GetAppIDs()
+	}
+
+	require.Equal(t, []string{"server", "db", "vscode"}, filtered(launch.AppFilter{Only: []string{"editor", "terminal"}}))
+	require.Equal(t, []string{"server", "db", "vscode"}, filtered(launch.AppFilter{Skip: []string{"browser"}}))
+	require.Equal(t, []string{"browser", "server", "vscode"}, filtered(launch.AppFilter{Tags: []string{"frontend"}}))
+	require.Equal(t, []string{"server"}, filtered(launch.AppFilter{Tags: []string{"frontend"}, Skip: []string{"vscode", "brave"}}))
+
+	err = plan.Filter(launch.AppFilter{Only: []string{"emacs"}})
+	require.ErrorIs(t, err, launch.ErrNoMatchingApp)
+	err = plan.Filter(launch.AppFilter{Tags: []string{"mobile"}})
+	require.ErrorContains(t, err, "tag:mobile")
+}
+
+func TestSubset_RejectsDuplicateIDs(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "dup", `version: 1
+apps:
+  vscode:
+    - id: main
+  browser:
+    - id: main
+`)
+
+	_, err := launch.NewLaunchPlan(cfg, "dup", "")
+	require.ErrorContains(t, err, "duplicate app id 'main'")
+}
+
+func TestSubset_DefaultIDsAreStable(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	ids := func(spec, profile string) []string {
+		writeSpec(t, cfg, tempDir, "tabs", spec)
+		plan, err := launch.NewLaunchPlan(cfg, "tabs", profile)
+		require.NoError(t, err)
+		return plan.GetAppIDs()
+	}
+
+	mail := "    - tabs: [\"https://mail.example.com\"]\n"
+	chat := "    - tabs: [\"https://chat.example.com\"]\n"
+	docs := "    - tabs: [\"https://docs.example.com\"]\n      id: docs\n"
+	profiles := "profiles:\n  quiet:\n    disable: [docs]\n"
+
+	before := ids("version: 1\napps:\n  browser:\n"+mail+chat+docs+profiles, "")
+	require.Len(t, before, 3)
+	require.Regexp(t, `^browser-[0-9a-f]{6}$`, before[0])
+	require.NotEqual(t, before[0], before[1])
+
+	// inserting an entry in front doesn't rename the others
+	after := ids("version: 1\napps:\n  browser:\n    - tabs: [\"https://news.example.com\"]\n"+mail+chat+docs+profiles, "")
+	require.Equal(t, before, after[1:])
+
+	// nor does a profile leaving one out, or removing one
+	require.Equal(t, before[:2], ids("version: 1\napps:\n  browser:\n"+mail+chat+docs+profiles, "quiet"))
+	require.Equal(t, before[1:], ids("version: 1\napps:\n  browser:\n"+chat+docs+profiles, ""))
+
+	// identical entries are numbered, a single entry is named after its type
+	require.Equal(t, []string{before[0], before[0] + "-2"}, ids("version: 1\napps:\n  browser:\n"+mail+mail, ""))
+	require.Equal(t, []string{"browser"}, ids("version: 1\napps:\n  browser:\n"+mail, ""))
+}
+
+func TestSubset_LaunchDryRunHonoursFlags(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--dry-run", "--only", "editor,terminal"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "[vscode]")
+	require.Contains(t, string(out), "npm run dev")
+	require.NotContains(t, string(out), "localhost:3000")
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--dry-run", "--tags", "backend", "--skip", "vscode"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "docker compose up db")
+	require.NotContains(t, string(out), "[vscode]")
+	require.NotContains(t, string(out), "npm run dev")
+}
+
+func TestSubset_AddTopsUpActiveWorkspace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	// an active workspace with nothing running yet
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+
+	// the runtime remembers which apps are running
+	rtFile := filepath.Join(cfg.RuntimeWspDir(), "web.json")
+	data, err := os.ReadFile(rtFile)
+	require.NoError(t, err)
+	var rt map[string]any
+	require.NoError(t, json.Unmarshal(data, &rt))
+	require.Equal(t, []any{}, rt["apps"])
+
+	// --add sticks to the profile the workspace was launched with
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--add", "editor", "--dry-run"})
+	require.ErrorIs(t, err, launch.ErrNoMatchingApp)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--add", "editor", "--profile", "full", "--dry-run"})
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "already active")
+	require.Contains(t, string(out), "Apps to add")
+	require.Contains(t, string(out), "[vscode]")
+	require.NotContains(t, string(out), "npm run dev")
+
+	// pretend the editor and the server are running
+	rt["apps"] = []string{"vscode", "server"}
+	rt["pids"] = [][]int{{}, {}}
+	data, err = json.Marshal(rt)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(rtFile, data, 0644))
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--add", "editor,server", "-p", "full"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "App 'vscode' is already running.")
+	require.Contains(t, string(out), "App 'server' is already running.")
+	require.Contains(t, string(out), "Nothing to add.")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--add", "editor", "--force"})
+	require.ErrorContains(t, err, "--add can't be combined")
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func wspStatus(t *testing.T, cfg *utils.ZestConfig, name string) workspace.Status {
+	reg, err := workspace.NewWspRegistry(cfg)
+	require.NoError(t, err)
+	wspCfg, ok := reg.GetCfg(name)
+	require.True(t, ok)
+	return wspCfg.Status
+}
+
+func TestSwitch_ClosesAndLaunches(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "work", "version: 1\ngroup: office\napps: {}\n")
+	writeSpec(t, cfg, tempDir, "mail", "version: 1\ngroup: office\napps: {}\n")
+	writeSpec(t, cfg, tempDir, "music", "version: 1\napps: {}\n")
+	writeSpec(t, cfg, tempDir, "home", "version: 1\napps: {}\n")
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "-"})
+	require.ErrorIs(t, err, cmd.ErrNoPreviousWorkspace)
+
+	for _, name := range []string{"work", "mail", "music"} {
+		_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", name})
+		require.NoError(t, err)
+	}
+	require.Equal(t, "office", loadRuntime(t, cfg, "work").Group)
+
+	// only the office workspaces are closed
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "home", "--close-group", "office"})
+	require.NoError(t, err)
+	require.Equal(t, "Switched to 'home' (closed mail, work).\n", string(out))
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "work"))
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "mail"))
+	require.Equal(t, workspace.Active, wspStatus(t, cfg, "music"))
+	require.Equal(t, workspace.Active, wspStatus(t, cfg, "home"))
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "work"})
+	require.NoError(t, err)
+	require.Equal(t, "Switched to 'work' (closed home, music).\n", string(out))
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "music"))
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "nope"})
+	require.ErrorIs(t, err, workspace.ErrWorkspaceNotExists)
+}
+
+func TestSwitch_RollsBackFailedLaunch(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "work", "version: 1\napps: {}\n")
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none", "--env", "MODE=dev"})
+	require.NoError(t, err)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "work", "-p", "missing"})
+	require.ErrorIs(t, err, launch.ErrProfileNotExists)
+	require.ErrorContains(t, err, "restored web")
+	require.NotContains(t, string(out), "Switched")
+
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "work"))
+	require.Equal(t, workspace.Active, wspStatus(t, cfg, "web"))
+	rt := loadRuntime(t, cfg, "web")
+	require.Equal(t, "none", rt.Profile)
+	require.Equal(t, map[string]string{"MODE": "dev"}, rt.Launch.Env)
+}
+
+func TestSwitch_ReturnsToPrevious(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "work", "version: 1\napps: {}\n")
+	writeSpec(t, cfg, tempDir, "home", "version: 1\napps: {}\n")
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "work"})
+	require.NoError(t, err)
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "home"})
+	require.NoError(t, err)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "-"})
+	require.NoError(t, err)
+	require.Equal(t, "Switched to 'work' (closed home).\n", string(out))
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "-"})
+	require.NoError(t, err)
+	require.Equal(t, "Switched to 'home' (closed work).\n", string(out))
+}
+
+func TestSwitch_StopsTargetOfFailedLaunch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the app below runs sleep")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	writeSpec(t, cfg, tempDir, "web", "version: 1\napps: {}\n")
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+apps:
+  custom:
+    - {id: sleeper, name: sleep, cmd: sleep, args: ["31"]}
+hooks:
+  post_launch:
+    - cmd: exit 3
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web"})
+	require.NoError(t, err)
+
+	before, err := utils.ListPIDs("sleep")
+	require.NoError(t, err)
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"switch", "work"})
+	require.ErrorIs(t, err, launch.ErrHookFailed)
+	require.ErrorContains(t, err, "restored web")
+	require.NotContains(t, string(out), "Launching workspace")
+
+	// the target started its app before the hook failed, it must not keep running
+	require.Equal(t, workspace.Inactive, wspStatus(t, cfg, "work"))
+	require.Equal(t, workspace.Active, wspStatus(t, cfg, "web"))
+	require.Contains(t, sessionLog(t, cfg, "work"), "post_launch: exit 3")
+
+	after, err := utils.ListPIDs("sleep")
+	require.NoError(t, err)
+	for _, pid := range after {
+		if !slices.Contains(before, pid) {
+			require.Eventually(t, func() bool { return exited(pid) }, 5*time.Second, 100*time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/AVAniketh0905/zest/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_BuiltinRendersLaunchableWorkspace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	repo := filepath.Join(tempDir, "src", "api")
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "api", "--template", "go-dev", "--set", "repo=" + repo})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(cfg.WspDir(), "api.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "name: api")
+	require.Contains(t, string(data), repo)
+
+	out, err := setupAndRun(rootCmd, tempDir, []string{"launch", "api", "--dry-run"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "[vscode] Opening folder: "+repo)
+	require.Contains(t, string(out), "https://pkg.go.dev/std")
+	if runtime.GOOS == "windows" {
+		require.Contains(t, string(out), "go build ./...")
+	} else {
+		require.NotContains(t, string(out), "[powershell]")
+	}
+
+	reg, err := workspace.NewWspRegistry(cfg)
+	require.NoError(t, err)
+	wspCfg, ok := reg.GetCfg("api")
+	require.True(t, ok)
+	require.Equal(t, "go-dev", wspCfg.Template)
+}
+
+func TestTemplate_BuiltinsLaunchOnThisSystem(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+
+	builtins := map[string][]string{
+		"go-dev":             {"repo=" + tempDir},
+		"web-dev":            {"repo=" + tempDir},
+		"research-with-pdfs": {"notes=" + tempDir, "pdfs=a.pdf"},
+		"writing":            {"drafts=" + tempDir},
+	}
+	for name, vars := range builtins {
+		args := []string{"init", name, "--template", name}
+		for _, v := range vars {
+			args = append(args, "--set", v)
+		}
+		_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, args)
+		require.NoError(t, err, name)
+
+		plan, err := launch.NewLaunchPlan(cfg, name, "")
+		require.NoError(t, err, name)
+		require.NotEmpty(t, plan.Apps, name)
+		require.NoError(t, plan.CheckSupported(), name)
+		for _, app := range plan.Apps {
+			require.True(t, launch.Supported(app.Meta().Backend()), "%s: %s", name, app.Meta().ID)
+		}
+	}
+}
+
+func TestTemplate_ExpandsHomeAndSplitsLists(t *testing.T) {
+	tempDir := setupTempDir(t)
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"init", "papers", "-t", "research-with-pdfs",
+		"--set", "notes=~/notes", "--set", "pdfs=a.pdf, b.pdf"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(cfg.WspDir(), "papers.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), filepath.Join(home, "notes"))
+	require.Contains(t, string(data), `- "a.pdf"`)
+	require.Contains(t, string(data), `- "b.pdf"`)
+}
+
+func TestTemplate_UserTemplateOverridesBuiltin(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	require.NoError(t, cfg.EnsureDirs())
+	tmpl := `description: My own go setup
+variables:
+  - name: cmd
+    default: htop
+---
+version: 1
+name: {{ .name }}
+apps:
+  custom:
+    - name: monitor
+      cmd: {{ quote .cmd }}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.TemplateDir(), "go-dev.yaml"), []byte(tmpl), 0644))
+
+	rootCmd := cmd.NewRootCmd(cfg)
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "mine", "--template", "go-dev"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(cfg.WspDir(), "mine.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `cmd: "htop"`)
+	require.NotContains(t, string(data), "vscode")
+}
+
+func TestTemplate_PromptsForMissingVariables(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+	rootCmd.SetIn(strings.NewReader("\n/tmp/drafts\n"))
+
+	out, err := setupAndRun(rootCmd, tempDir, []string{"init", "book", "--template", "writing"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "drafts (")
+
+	data, err := os.ReadFile(filepath.Join(cfg.WspDir(), "book.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "/tmp/drafts")
+
+	// no input left, the missing variable is an error
+	rootCmd.SetIn(strings.NewReader(""))
+	_, err = setupAndRun(rootCmd, tempDir, []string{"init", "book2", "--template", "writing"})
+	require.ErrorContains(t, err, "no value given for template variable 'drafts'")
+}
+
+func TestTemplate_RejectsUnknownTemplateAndVariables(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	rootCmd := cmd.NewRootCmd(cfg)
+
+	_, err := setupAndRun(rootCmd, tempDir, []string{"init", "x", "--template", "nope"})
+	require.ErrorContains(t, err, "template does not exist")
+
+	_, err = setupAndRun(rootCmd, tempDir, []string{"init", "x", "--template", "go-dev", "--set", "repo=.", "--set", "colour=red"})
+	require.ErrorContains(t, err, "has no variable 'colour'")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", "x", "--set", "repo=."})
+	require.ErrorContains(t, err, "--set can only be used with --template")
+
+	_, err = os.Stat(filepath.Join(cfg.WspDir(), "x.yaml"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestTemplate_RejectsInvalidMetadata(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	require.NoError(t, cfg.EnsureDirs())
+	tmpl := "variables:\n  - name: dir\n---\nversion: 1\nname: {{ .name }}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.TemplateDir(), "bare.yaml"), []byte(tmpl), 0644))
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", "x", "--template", "bare"})
+	require.ErrorContains(t, err, "description is missing")
+}
+
+func TestTemplateCommand_ListAndShow(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "list", "--json"})
+	require.NoError(t, err)
+
+	var infos []cmd.TemplateInfo
+	require.NoError(t, json.Unmarshal(out, &infos))
+	names := []string{}
+	for _, info := range infos {
+		names = append(names, info.Name)
+		require.Equal(t, "builtin", info.Source)
+		require.NotEmpty(t, info.Description)
+	}
+	require.Equal(t, []string{"go-dev", "research-with-pdfs", "web-dev", "writing"}, names)
+
+	out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "show", "go-dev"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Description: Go project")
+	require.Contains(t, string(out), "repo  required")
+	require.Contains(t, string(out), "workspace_dir: {{ quote .repo }}")
+}
+
+func TestTemplateCommand_ListSkipsMalformedTemplate(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	require.NoError(t, cfg.EnsureDirs())
+	tmpl := "variables:\n  - name: dir\n---\nversion: 1\nname: {{ .name }}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.TemplateDir(), "bare.yaml"), []byte(tmpl), 0644))
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "list", "--json"})
+	require.NoError(t, err)
+
+	var infos []cmd.TemplateInfo
+	require.NoError(t, json.Unmarshal(out, &infos))
+	names := []string{}
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	require.Equal(t, []string{"go-dev", "research-with-pdfs", "web-dev", "writing"}, names)
+}
+
+func TestTemplateCommand_SaveParameterizesWorkspace(t *testing.T) {
+	tempDir := setupTempDir(t)
+	repo := filepath.Join(tempDir, "src", "api")
+	other := filepath.Join(tempDir, "src", "billing")
+
+	cfg := &utils.ZestConfig{}
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", "api", "-t", "go-dev", "--set", "repo=" + repo})
+	require.NoError(t, err)
+
+	spec := filepath.Join(cfg.WspDir(), "api.yaml")
+	f, err := os.OpenFile(spec, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("env:\n  CONF: " + filepath.Join(repo, "conf", "dev.yaml") + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	out, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "save", "api", "my-go", "-d", "My Go setup"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Template 'my-go' saved")
+
+	data, err := os.ReadFile(filepath.Join(cfg.TemplateDir(), "my-go.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "description: My Go setup")
+	require.Contains(t, string(data), "name: workspace_dir")
+	require.NotContains(t, string(data), repo)
+
+	// saving again needs --force
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "save", "api", "my-go"})
+	require.ErrorContains(t, err, "template already exists")
+
+	// the required variable is validated by init
+	rootCmd := cmd.NewRootCmd(cfg)
+	rootCmd.SetIn(strings.NewReader(""))
+	_, err = setupAndRun(rootCmd, tempDir, []string{"init", "billing", "-t", "my-go"})
+	require.ErrorContains(t, err, "no value given for template variable 'workspace_dir'")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", "billing", "-t", "my-go", "--set", "workspace_dir=" + other})
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(filepath.Join(cfg.WspDir(), "billing.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "name: billing")
+	require.Contains(t, string(data), `path: "`+other+`"`)
+	require.Contains(t, string(data), filepath.Join(other, "conf", "dev.yaml"))
+	require.NotContains(t, string(data), repo)
+}
+
+func TestTemplateCommand_Delete(t *testing.T) {
+	tempDir := setupTempDir(t)
+
+	cfg := &utils.ZestConfig{}
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", "api"})
+	require.NoError(t, err)
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "save", "api", "empty"})
+	require.NoError(t, err)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "delete", "empty"})
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(cfg.TemplateDir(), "empty.yaml"))
+	require.True(t, os.IsNotExist(err))
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "delete", "empty"})
+	require.ErrorContains(t, err, "template does not exist")
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "delete", "go-dev"})
+	require.ErrorContains(t, err, "built-in templates can't be changed")
+}
+
+func TestTemplateCommand_SaveParameterizesEveryPath(t *testing.T) {
+	tempDir := setupTempDir(t)
+	repo := filepath.Join(tempDir, "src", "api")
+	other := filepath.Join(tempDir, "src", "billing")
+
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "api", `version: 1
+workspace_dir: `+repo+`
+env_files:
+  - `+filepath.Join(repo, ".env")+`
+hooks:
+  pre_launch:
+    - cmd: make deps
+      cwd: `+filepath.Join(repo, "scripts")+`
+apps: {}
+`)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"template", "save", "api", "my-api"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(cfg.TemplateDir(), "my-api.yaml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), repo)
+
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"init", "billing", "-t", "my-api", "--set", "workspace_dir=" + other})
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(filepath.Join(cfg.WspDir(), "billing.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), filepath.Join(other, ".env"))
+	require.Contains(t, string(data), filepath.Join(other, "scripts"))
+	require.NotContains(t, string(data), repo)
+}
@@ -0,0 +1,190 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AVAniketh0905/zest/cmd"
+	"github.com/AVAniketh0905/zest/internal/launch"
+	"github.com/AVAniketh0905/zest/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a buffer a running command can write to while a test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatch_EnvFiles(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env"), []byte("MODE=dev\nPORT=3000\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env.local"), []byte("PORT=4000\n"), 0644))
+	writeSpec(t, cfg, tempDir, "web", `version: 1
+workspace_dir: `+filepath.ToSlash(tempDir)+`
+env:
+  MODE: prod
+  NAME: web
+env_files: [.env, .env.local]
+apps: {}
+`)
+
+	plan, err := launch.NewLaunchPlan(cfg, "web", "")
+	require.NoError(t, err)
+	require.Equal(t, "dev", plan.Env["MODE"])
+	require.Equal(t, "4000", plan.Env["PORT"])
+	require.Equal(t, "web", plan.Env["NAME"])
+	require.Contains(t, plan.WatchFiles(), filepath.Join(tempDir, ".env.local"))
+	require.Contains(t, plan.WatchFiles(), filepath.Join(cfg.WspDir(), "web.yaml"))
+
+	require.NoError(t, os.Remove(filepath.Join(tempDir, ".env.local")))
+	_, err = launch.NewLaunchPlan(cfg, "web", "")
+	require.ErrorContains(t, err, "failed to read env file")
+}
+
+func TestWatch_AppliesValidEdits(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{}
+	writeSpec(t, cfg, tempDir, "web", subsetSpec)
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--profile", "none"})
+	require.NoError(t, err)
+
+	plan, err := launch.NewLaunchPlan(cfg, "web", "full")
+	require.NoError(t, err)
+	setRuntimeApps(t, cfg, "web", "full", []string{"vscode", "db"}, [][]int{{}, {}})
+	setRuntimeField(t, cfg, "web", "hashes", plan.GetHashes())
+	setRuntimeField(t, cfg, "web", "launch", map[string]any{"filter": map[string]any{"only": []string{"vscode"}}})
+
+	// nothing is left to add, so the launch goes straight to watching
+	out := &syncBuffer{}
+	root := cmd.NewRootCmd(&utils.ZestConfig{})
+	root.SetOut(out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"launch", "web", "--add", "vscode", "--watch", "--custom", tempDir})
+	done := make(chan error, 1)
+	go func() { done <- root.Execute() }()
+
+	seen := func(s string) func() bool {
+		return func() bool { return strings.Contains(out.String(), s) }
+	}
+	require.Eventually(t, seen("Watching"), 5*time.Second, 20*time.Millisecond)
+
+	specFile := filepath.Join(cfg.WspDir(), "web.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte("apps: [\n"), 0644))
+	require.Eventually(t, seen("Keeping the current state of 'web'."), 5*time.Second, 20*time.Millisecond)
+	require.Equal(t, []string{"vscode", "db"}, loadRuntime(t, cfg, "web").Apps)
+
+	require.NoError(t, os.WriteFile(specFile, []byte(strings.Replace(subsetSpec, `    - id: db
+      tabs: ["docker compose up db"]
+      tags: [backend]
+`, "", 1)), 0644))
+	require.Eventually(t, seen("Workspace 'web' applied: 0 added, 1 removed, 0 changed."), 5*time.Second, 20*time.Millisecond)
+	require.Equal(t, []string{"vscode"}, loadRuntime(t, cfg, "web").Apps)
+
+	// closing the workspace ends the watch
+	_, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "web"})
+	require.NoError(t, err)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop after the workspace was closed")
+	}
+	require.Contains(t, out.String(), "no longer active")
+}
+
+func TestWatch_DetachedLaunchDoesntWatch(t *testing.T) {
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "launch:\n  watch: true\n")
+	writeSpec(t, cfg, tempDir, "web", "version: 1\napps: {}\n")
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--detach", "--watch"})
+	require.ErrorContains(t, err, "--watch can't be combined with --detach")
+
+	done := make(chan error, 1)
+	var out []byte
+	go func() {
+		var err error
+		out, err = setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"launch", "web", "--detach"})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("a detached launch kept watching")
+	}
+	require.Contains(t, string(out), "Workspace 'web' launched successfully.")
+	require.NotContains(t, string(out), "Watching")
+}
+
+func TestWatch_EnvFileEditRestartsApps(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("reads the environment of the app from /proc")
+	}
+
+	tempDir := setupTempDir(t)
+	cfg := &utils.ZestConfig{ZestDir: tempDir}
+	writeGlobalConfig(t, cfg, "close:\n  grace_period: 0s\n")
+	envFile := filepath.Join(tempDir, ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("MODE=test\n"), 0644))
+	writeSpec(t, cfg, tempDir, "work", `version: 1
+workspace_dir: `+filepath.ToSlash(tempDir)+`
+env_files: [.env]
+apps:
+  custom:
+    - {id: sleeper, name: sleep, cmd: sleep, args: ["38"]}
+`)
+
+	out := &syncBuffer{}
+	root := cmd.NewRootCmd(cfg)
+	root.SetOut(out)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"launch", "work", "--watch", "--custom", tempDir})
+	done := make(chan error, 1)
+	go func() { done <- root.Execute() }()
+
+	seen := func(s string) func() bool {
+		return func() bool { return strings.Contains(out.String(), s) }
+	}
+	require.Eventually(t, seen("Watching"), 5*time.Second, 20*time.Millisecond)
+	before := loadRuntime(t, cfg, "work").PIDs[0]
+	require.Contains(t, procEnv(t, before[0]), "MODE=test")
+
+	require.NoError(t, os.WriteFile(envFile, []byte("MODE=dev\n"), 0644))
+	require.Eventually(t, seen("Workspace 'work' applied: 0 added, 0 removed, 1 changed."), 5*time.Second, 20*time.Millisecond)
+	after := loadRuntime(t, cfg, "work").PIDs[0]
+	require.NotEqual(t, before, after)
+	require.Contains(t, procEnv(t, after[0]), "MODE=dev")
+
+	_, err := setupAndRun(cmd.NewRootCmd(cfg), tempDir, []string{"close", "work"})
+	require.NoError(t, err)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop after the workspace was closed")
+	}
+}